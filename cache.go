@@ -50,3 +50,9 @@ func (c *cache) clear(prefix string) int {
 	}
 	return count
 }
+
+func (c *cache) unset(key string) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	delete(c.data, key)
+}
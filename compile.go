@@ -0,0 +1,119 @@
+package gpath
+
+// Path is a precompiled path, which can be used repeatedly with any GPath instance without being
+// parsed again
+type Path struct {
+	segments  []segment
+	canonical string
+	cacheable bool
+}
+
+// Compile parses given path (see GPath.Get for the notation) into a reusable Path. An error is
+// returned, if the path is malformed.
+func Compile(path string) (*Path, error) {
+	segments, err := parsePath(path)
+	if err != nil {
+		return nil, err
+	}
+	return newPath(segments), nil
+}
+
+// ParsePath works as Compile, but is strict about the syntax: empty segments (`a..b`), trailing
+// separators (`a.b.`) and empty paths are rejected, instead of being read as empty keys. Errors are of
+// type *PathError, which provides the offset of the problem.
+func ParsePath(path string) (*Path, error) {
+	segments, err := splitPath(path, ".", true)
+	if err != nil {
+		return nil, err
+	}
+	return newPath(segments), nil
+}
+
+// MustCompile works as Compile, but panics if the path is malformed
+func MustCompile(path string) *Path {
+	p, err := Compile(path)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// P starts building a path programmatically, eg `P().Key("hosts").Key("example.com").Index(3)`. Keys
+// are taken literally, so they need no escaping.
+func P() *Path {
+	return newPath(nil)
+}
+
+// Key returns a new path, which addresses given key (of a map) or field (of a struct) below the path
+func (p *Path) Key(key string) *Path {
+	return newPath(appendSegment(p.segments, keySegment(key)))
+}
+
+// Index returns a new path, which addresses given index of a slice below the path. Negative indices
+// count from the end of the slice.
+func (p *Path) Index(idx int) *Path {
+	return newPath(appendSegment(p.segments, indexSegment(idx)))
+}
+
+func newPath(segments []segment) *Path {
+	return &Path{
+		segments:  segments,
+		canonical: formatPath(segments),
+		cacheable: cacheable(segments),
+	}
+}
+
+// String returns the canonical notation of the path, which can be used wherever a path in the default
+// dotted notation is accepted. Keys are escaped as required. See Format for GPath instances with another
+// separator or dialect.
+func (p *Path) String() string {
+	return p.canonical
+}
+
+// Format returns the notation of the path in the separator and dialect of given GPath (see WithSeparator
+// and WithDialect), which can be used with all its methods accepting a path
+func (p *Path) Format(gp *GPath) string {
+	return gp.format(p.segments)
+}
+
+// Has returns bool whether the path exists in given GPath
+func (p *Path) Has(gp *GPath) bool {
+	_, has := p.get(gp)
+	return has
+}
+
+// Get returns the value of the path in given GPath - or nil, if it does not exist
+func (p *Path) Get(gp *GPath) interface{} {
+	val, _ := p.get(gp)
+	return val
+}
+
+// Set writes the value at the path in given GPath, see GPath.Set
+func (p *Path) Set(gp *GPath, value interface{}) error {
+	return gp.set(p.segments, value)
+}
+
+// SetDeep writes the value at the path in given GPath and creates missing parents, see GPath.SetDeep
+func (p *Path) SetDeep(gp *GPath, value interface{}) error {
+	return gp.setDeep(p.segments, value)
+}
+
+// Delete removes the element of the path from given GPath, see GPath.Delete
+func (p *Path) Delete(gp *GPath) error {
+	return gp.delete(p.segments)
+}
+
+// Query returns all matches of the path in given GPath, see GPath.Query. The paths of the matches are
+// in the notation of the GPath.
+func (p *Path) Query(gp *GPath) []Match {
+	return gp.queryPath(p.segments)
+}
+
+func (p *Path) get(gp *GPath) (interface{}, bool) {
+	if len(p.segments) == 0 {
+		return gp.source, true
+	} else if !p.cacheable {
+		return followPath(p.segments, gp.source)
+	}
+	return gp.lookup(p.segments, p.canonical)
+}
@@ -0,0 +1,176 @@
+package gpath
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCompile(t *testing.T) {
+	expects := []struct {
+		path   string
+		expect string
+	}{
+		{"a.b.0.c", "a.b.0.c"},
+		{`hosts["example.com"].port`, `hosts["example.com"].port`},
+		{`hosts.example\.com.port`, `hosts["example.com"].port`},
+		{"users[0].roles[-1]", "users.0.roles.-1"},
+		{"users.*.name", "users.*.name"},
+		{"items[1:3]", "items[1:3]"},
+	}
+	for _, expect := range expects {
+		p, err := Compile(expect.path)
+		assert.Nil(t, err, "Path %s compiles", expect.path)
+		assert.Equal(t, expect.expect, p.String(), "Canonical notation of %s", expect.path)
+		assert.Equal(t, expect.expect, MustCompile(p.String()).String(), "Canonical notation of %s parses back", expect.path)
+	}
+
+	for _, path := range []string{"a[", `a["b`, "a]", `a\`, "a[?@ ==]"} {
+		p, err := Compile(path)
+		assert.NotNil(t, err, "Path %s does not compile", path)
+		assert.Nil(t, p, "Path %s does not compile", path)
+		assert.Panics(t, func() { MustCompile(path) }, "Path %s does not compile", path)
+	}
+}
+
+func TestPath_Get(t *testing.T) {
+	gp := _newGPath()
+	expects := []struct {
+		path   string
+		expect interface{}
+		has    bool
+	}{
+		{"string", "bar", true},
+		{"ints.1", 4, true},
+		{"ints[-1]", 5, true},
+		{"complex.inner.0", "str", true},
+		{"complex.inner[1:]", []interface{}{123, 12.5}, true},
+		{"complex.other", nil, false},
+		{"strings.*", nil, false},
+	}
+	for _, expect := range expects {
+		p := MustCompile(expect.path)
+		for i := 0; i < 2; i++ {
+			assert.Equal(t, expect.expect, p.Get(gp), "Get %s", expect.path)
+			assert.Equal(t, expect.has, p.Has(gp), "Has %s", expect.path)
+			assert.Equal(t, gp.Get(expect.path), p.Get(gp), "Get %s equals GPath.Get", expect.path)
+		}
+	}
+
+	p := MustCompile("users.*.email")
+	assert.Equal(t, New(_testQueryData).Query("users.*.email"), p.Query(New(_testQueryData)))
+}
+
+func TestPath_Set(t *testing.T) {
+	data := map[string]interface{}{"a": map[string]interface{}{"b": 1}, "list": []interface{}{1}}
+	gp := New(data)
+	p := MustCompile("a.b")
+	assert.Equal(t, 1, p.Get(gp))
+	assert.Nil(t, p.Set(gp, 2))
+	assert.Equal(t, 2, p.Get(gp), "cache is updated")
+	assert.Equal(t, 2, gp.Get("a.b"))
+
+	other := New(map[string]interface{}{"a": map[string]interface{}{"b": 3}})
+	assert.Equal(t, 3, p.Get(other), "path is independent of GPath")
+
+	assert.Nil(t, MustCompile("list.-1").Set(gp, 2), "-1 appends")
+	assert.Equal(t, []interface{}{1, 2}, gp.Get("list"))
+	assert.NotNil(t, MustCompile("list.*").Set(gp, 3), "cannot write to wildcard")
+	assert.NotNil(t, MustCompile("x.y").Set(gp, 3), "parent must exist")
+
+	assert.Nil(t, MustCompile("a.b").Delete(gp))
+	assert.False(t, p.Has(gp), "cache is updated")
+	assert.Equal(t, ErrNotFound, p.Delete(gp))
+}
+
+func TestP(t *testing.T) {
+	assert.Equal(t, "hosts[\"example.com\"].3", P().Key("hosts").Key("example.com").Index(3).String())
+	assert.Equal(t, "", P().String())
+
+	keys := []string{"plain", "a.b", "a[0]", `quote"d`, `back\slash`, "", "*", "**", "0", "-1", "[?@]", "it's", "1:2"}
+	for _, key := range keys {
+		p := P().Key("m").Key(key).Index(-1)
+		segments, err := parsePath(p.String())
+		assert.Nil(t, err, "Path of key %q parses", key)
+		assert.Equal(t, p.segments, segments, "Path of key %q parses back into same segments", key)
+	}
+
+	base := P().Key("users")
+	first, last := base.Index(0), base.Index(-1)
+	assert.Equal(t, "users", base.String(), "builder does not modify its base")
+	assert.Equal(t, "users.0", first.String())
+	assert.Equal(t, "users.-1", last.String())
+
+	data := map[string]interface{}{
+		"hosts": map[string]interface{}{
+			"example.com": map[string]interface{}{"ports": []interface{}{80, 443}},
+		},
+	}
+	gp := New(data)
+	p := P().Key("hosts").Key("example.com").Key("ports").Index(1)
+	assert.Equal(t, 443, p.Get(gp))
+	assert.Equal(t, 443, gp.Get(p.String()), "built path can be used as string")
+	assert.Equal(t, int64(443), gp.GetInt(p.String()))
+	assert.Nil(t, P().Key("hosts").Key("example.com").Key("ports").Index(-1).Set(gp, 8080))
+	assert.Equal(t, []interface{}{80, 443, 8080}, gp.Get(`hosts["example.com"].ports`))
+	assert.Nil(t, gp.Set(P().Key("hosts").Key("a.b").String(), 1), "built path can be used with Set")
+	assert.Equal(t, 1, data["hosts"].(map[string]interface{})["a.b"])
+
+	sgp := New(data, WithSeparator("/"))
+	assert.Equal(t, "hosts/example.com/ports/1", p.Format(sgp))
+	assert.Equal(t, 443, sgp.Get(p.Format(sgp)), "formatted path can be used with separator")
+	pgp := New(data, WithDialect(Pointer))
+	assert.Equal(t, "/hosts/a.b", P().Key("hosts").Key("a.b").Format(pgp))
+	assert.Equal(t, 1, pgp.Get(P().Key("hosts").Key("a.b").Format(pgp)), "formatted path can be used with dialect")
+	assert.Equal(t, p.String(), p.Format(gp), "default notation")
+
+	assert.Equal(t, data, P().Get(gp), "empty path addresses the whole data structure")
+	assert.NotNil(t, P().Set(gp, 1), "cannot write to empty path")
+}
+
+func TestParsePath(t *testing.T) {
+	for _, path := range []string{"a.b.0.c", `a[""].b`, `a\..b`, "a[0][1].b", "*.**.x", `hosts["example.com"]`} {
+		p, err := ParsePath(path)
+		assert.Nil(t, err, "Path %s is valid", path)
+		assert.Equal(t, MustCompile(path).String(), p.String(), "Path %s parses as with Compile", path)
+	}
+
+	expects := []struct {
+		path   string
+		offset int
+		reason string
+		caret  string
+	}{
+		{"a..b", 2, "empty segment", "a..b\n  ^"},
+		{".a", 0, "empty segment", ".a\n^"},
+		{"a.[0]", 2, "empty segment", "a.[0]\n  ^"},
+		{"a.b.", 3, "trailing separator", "a.b.\n   ^"},
+		{"", 0, "empty path", "\n^"},
+		{"a[0", 1, "unclosed bracket", "a[0\n ^"},
+		{`a["b`, 1, "unterminated quote of bracket", "a[\"b\n ^"},
+		{"a]", 1, `unexpected ']'`, "a]\n ^"},
+		{"a[0]b", 4, `unexpected 'b'`, "a[0]b\n    ^"},
+		{`a.b\`, 3, "dangling escape", "a.b\\\n   ^"},
+		{"a[x]", 2, `expected quote or index after "["`, "a[x]\n  ^"},
+		{"ä..b", 3, "empty segment", "ä..b\n  ^"},
+		{"a.99999999999999999999", 2, "index 99999999999999999999 out of range", "a.99999999999999999999\n  ^"},
+	}
+	for _, expect := range expects {
+		p, err := ParsePath(expect.path)
+		assert.Nil(t, p, "Path %s is invalid", expect.path)
+		if assert.IsType(t, &PathError{}, err, "Path %s is invalid", expect.path) {
+			perr := err.(*PathError)
+			assert.Equal(t, expect.path, perr.Path)
+			assert.Equal(t, expect.offset, perr.Offset, "Offset of %s", expect.path)
+			assert.Equal(t, expect.reason, perr.Reason, "Reason of %s", expect.path)
+			assert.Equal(t, expect.caret, perr.Caret(), "Caret of %s", expect.path)
+		}
+	}
+
+	_, err := ParsePath("users..name")
+	assert.EqualError(t, err, "empty segment at offset 6 in path users..name")
+	_, err = Compile("users[0")
+	assert.IsType(t, &PathError{}, err, "Compile returns positioned errors as well")
+	p, err := Compile("a..b")
+	assert.Nil(t, err, "Compile is lenient about empty segments")
+	assert.Equal(t, `a[""].b`, p.String())
+}
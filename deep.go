@@ -0,0 +1,101 @@
+package gpath
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// containers are the types of maps and slices, which SetDeep creates for missing parents
+type containers struct {
+	mapType   reflect.Type
+	sliceType reflect.Type
+}
+
+var defaultContainers = containers{
+	mapType:   reflect.TypeOf(map[string]interface{}{}),
+	sliceType: reflect.TypeOf([]interface{}{}),
+}
+
+// WithContainers sets the types of maps and slices, which SetDeep creates for missing parents, by
+// example values, eg WithContainers(map[interface{}]interface{}{}, []map[string]interface{}{}) as
+// YAML decoders produce. A nil (or any other than a map or slice) keeps the default, which is
+// map[string]interface{} and []interface{}.
+func WithContainers(mapExample, sliceExample interface{}) Option {
+	return func(gp *GPath) {
+		if t := reflect.TypeOf(mapExample); t != nil && t.Kind() == reflect.Map {
+			gp.containers.mapType = t
+		}
+		if t := reflect.TypeOf(sliceExample); t != nil && t.Kind() == reflect.Slice {
+			gp.containers.sliceType = t
+		}
+	}
+}
+
+// create returns a new, empty container for the segment which follows: a slice for indices, a map
+// otherwise
+func (c containers) create(next segment) interface{} {
+	if next.kind == segmentIndex {
+		return c.newSlice()
+	}
+	return c.newMap()
+}
+
+func (c containers) newMap() interface{} {
+	return reflect.MakeMap(c.mapType).Interface()
+}
+
+func (c containers) newSlice() interface{} {
+	return reflect.MakeSlice(c.sliceType, 0, 0).Interface()
+}
+
+// SetDeep works as Set, but creates missing (or nil) parents like `mkdir -p`: a slice, if the next
+// segment is an index, otherwise a map (see WithContainers). Within missing slices, only index 0 or -1
+// (append) can be used, as with Set. Existing values are never replaced by containers, so setting
+// `a.b` fails, if `a` is a string. Nothing is changed, if the value cannot be set.
+func (gp *GPath) SetDeep(path string, value interface{}) error {
+	segments, err := gp.parseDeep(path)
+	if err != nil {
+		return err
+	}
+	return gp.setDeep(segments, value)
+}
+
+func (gp *GPath) setDeep(segments []segment, value interface{}) error {
+	if !isConcrete(segments) {
+		return gp.set(segments, value)
+	}
+
+	// find the first missing parent, build everything below it separately and attach it with a single
+	// set, so that a failure does not leave empty containers behind
+	missing := 1
+	for ; missing < len(segments); missing++ {
+		if val, has := gp.getSegments(segments[0:missing]); !has || val == nil {
+			break
+		}
+	}
+	for i := len(segments) - 1; i >= missing; i-- {
+		container, err := gp.containers.put(segments[i], value)
+		if err != nil {
+			return fmt.Errorf("could not set %s in .%s (%s)", gp.format(segments[0:i+1]), gp.format(segments[0:i]), err)
+		}
+		value = container
+	}
+	return gp.set(segments[0:missing], value)
+}
+
+// put returns a new container for the segment (see create), which holds the value under it
+func (c containers) put(seg segment, value interface{}) (interface{}, error) {
+	if seg.kind == segmentIndex {
+		ptr := reflect.New(c.sliceType)
+		ptr.Elem().Set(vof(c.newSlice()))
+		if err := SliceIndexValueSet(ptr, seg.index, vof(value), true); err != nil {
+			return nil, err
+		}
+		return ptr.Elem().Interface(), nil
+	}
+	container := c.newMap()
+	if err := MapKeyValueSet(vof(container), vof(seg.key), vof(value), true); err != nil {
+		return nil, err
+	}
+	return container, nil
+}
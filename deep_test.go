@@ -0,0 +1,68 @@
+package gpath
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestGPath_SetDeep(t *testing.T) {
+	data := map[string]interface{}{"name": "doc", "empty": nil}
+	gp := New(data)
+
+	assert.NotNil(t, gp.Set("a.b.c", 1), "Set requires parents")
+	assert.Nil(t, gp.SetDeep("a.b.c", 1))
+	assert.Equal(t, map[string]interface{}{"b": map[string]interface{}{"c": 1}}, data["a"])
+	assert.Nil(t, gp.SetDeep("a.b.d", 2), "existing parents are kept")
+	assert.Equal(t, map[string]interface{}{"c": 1, "d": 2}, gp.Get("a.b"))
+
+	assert.Nil(t, gp.SetDeep("items.0.name", "first"), "numeric segment creates slice")
+	assert.Nil(t, gp.SetDeep("items.-1.id", 1), "-1 is last element")
+	assert.Nil(t, gp.SetDeep("items.1.tags.-1", "new"), "-1 appends")
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"name": "first", "id": 1},
+		map[string]interface{}{"tags": []interface{}{"new"}},
+	}, data["items"])
+	assert.Nil(t, gp.SetDeep("items[1].tags[1]", "other"))
+	assert.Equal(t, []string{"new", "other"}, gp.GetStrings("items.1.tags"))
+
+	assert.Nil(t, gp.SetDeep("empty.x", true), "nil parents are replaced")
+	assert.Equal(t, map[string]interface{}{"x": true}, data["empty"])
+
+	assert.NotNil(t, gp.SetDeep("name.first", "x"), "existing scalar is not replaced")
+	assert.Equal(t, "doc", data["name"])
+	assert.NotNil(t, gp.SetDeep("list.3", "x"), "index beyond end of new slice")
+	assert.EqualError(t, gp.SetDeep("q.3.y", "x"), "could not set q.3 in .q (provided index 3 is out of bounds for slice of len 0)")
+	assert.False(t, gp.Has("q"), "nothing is created, if the value cannot be set")
+	assert.NotNil(t, gp.SetDeep("a.*.x", 1), "cannot write to wildcard")
+
+	assert.Nil(t, MustCompile("c.d").SetDeep(gp, 3))
+	assert.Equal(t, 3, gp.Get("c.d"))
+}
+
+func TestGPath_SetDeepContainers(t *testing.T) {
+	data := map[interface{}]interface{}{}
+	gp := New(data, WithContainers(map[interface{}]interface{}{}, []map[interface{}]interface{}{}))
+	assert.Nil(t, gp.SetDeep("a.b", 1))
+	assert.Nil(t, gp.SetDeep("list.0.x", 2))
+	assert.Equal(t, map[interface{}]interface{}{
+		"a":    map[interface{}]interface{}{"b": 1},
+		"list": []map[interface{}]interface{}{{"x": 2}},
+	}, data)
+
+	idata := map[string]interface{}{}
+	assert.NotNil(t, New(idata, WithContainers(map[string]int{}, nil)).SetDeep("a.b.c", 1), "map cannot be value of container")
+	assert.Equal(t, map[string]interface{}{}, idata, "nothing is created, if the value cannot be set")
+
+	gp = New(map[string]interface{}{}, WithContainers(nil, "invalid"))
+	assert.Equal(t, defaultContainers, gp.containers, "invalid examples keep default")
+	assert.Equal(t, gp.containers, gp.child(nil).containers, "child uses same containers")
+
+	pdata := map[string]interface{}{}
+	pgp := New(pdata, WithDialect(Pointer))
+	assert.NotNil(t, pgp.SetPointer("/a/b", 1), "SetPointer requires parents")
+	assert.Nil(t, pgp.SetDeep("/a~1b/0/c", 1))
+	assert.Nil(t, pgp.SetDeep("/a~1b/-/c", 2))
+	assert.Equal(t, map[string]interface{}{
+		"a/b": []interface{}{map[string]interface{}{"c": 1}, map[string]interface{}{"c": 2}},
+	}, pdata)
+}
@@ -0,0 +1,60 @@
+package gpath
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrNotFound is returned by the error-returning getters (eg GetStringE), if the path does not exist
+var ErrNotFound = errors.New("path not found")
+
+// CastError is returned by the error-returning getters (eg GetStringE), if the value of the path exists
+// but cannot be cast into the requested type
+type CastError struct {
+	// Path is the path of the value
+	Path string
+
+	// SourceType is the type of the value, eg "[]interface {}"
+	SourceType string
+
+	// TargetType is the requested type, eg "int64"
+	TargetType string
+}
+
+// Error returns a description of the failure, eg `cannot cast []interface {} of path users to int64`
+func (e *CastError) Error() string {
+	return fmt.Sprintf("cannot cast %s of path %s to %s", e.SourceType, e.Path, e.TargetType)
+}
+
+// getE returns the value of the path, the error of a malformed path or ErrNotFound
+func (gp *GPath) getE(path string) (interface{}, error) {
+	segments, err := gp.parse(path, false)
+	if err != nil {
+		return nil, err
+	} else if val, has := gp.getSegments(segments); has {
+		return val, nil
+	}
+	return nil, ErrNotFound
+}
+
+// castError returns a *CastError for the value of path, which cannot be cast into the type of target
+func castError(path string, val interface{}, target interface{}) error {
+	source := "nil"
+	if val != nil {
+		source = fmt.Sprintf("%T", val)
+	}
+	return &CastError{Path: path, SourceType: source, TargetType: fmt.Sprintf("%T", target)}
+}
+
+// mustSucceed panics, if err is not nil. A path which does not exist panics with the *ResolveError
+// explaining why, all other errors (*CastError, *PathError) are used as they are.
+func (gp *GPath) mustSucceed(path string, err error) {
+	if err == ErrNotFound {
+		if _, rerr := gp.Resolve(path); rerr != nil {
+			err = rerr
+		}
+	}
+	if err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,71 @@
+package gpath
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCastError(t *testing.T) {
+	gp := New(map[string]interface{}{"users": []interface{}{"alice"}})
+
+	_, err := gp.GetIntE("users")
+	assert.EqualError(t, err, "cannot cast []interface {} of path users to int64")
+	cerr, ok := err.(*CastError)
+	if assert.True(t, ok, "error is *CastError") {
+		assert.Equal(t, "users", cerr.Path)
+		assert.Equal(t, "[]interface {}", cerr.SourceType)
+		assert.Equal(t, "int64", cerr.TargetType)
+	}
+	assert.NotEqual(t, ErrNotFound, err)
+
+	_, err = gp.GetIntE("groups")
+	assert.Equal(t, ErrNotFound, err, "missing path returns the sentinel itself")
+	_, ok = err.(*CastError)
+	assert.False(t, ok)
+
+	_, err = New(map[string]interface{}{"a": nil}).GetStringE("a")
+	assert.EqualError(t, err, "cannot cast nil of path a to string")
+
+	_, err = gp.GetStringE("users[0")
+	assert.IsType(t, &PathError{}, err, "malformed paths return *PathError")
+}
+
+func TestGPath_Must(t *testing.T) {
+	gp := New(map[string]interface{}{
+		"server": map[string]interface{}{"host": "localhost", "port": "8080", "tags": []interface{}{"a", "b"}},
+		"ratio":  0.5,
+		"debug":  true,
+	})
+	assert.Equal(t, "localhost", gp.MustString("server.host"))
+	assert.Equal(t, int64(8080), gp.MustInt("server.port"))
+	assert.Equal(t, 0.5, gp.MustFloat("ratio"))
+	assert.Equal(t, true, gp.MustBool("debug"))
+	assert.Equal(t, []string{"a", "b"}, gp.MustStrings("server.tags"))
+	assert.Equal(t, []string{"localhost"}, gp.MustStrings("server.host", true))
+	assert.Equal(t, "8080", gp.MustMapString("server")["port"])
+
+	expects := []struct {
+		fn     func()
+		expect string
+	}{
+		{func() { gp.MustString("server.user") }, `cannot resolve server.user: key missing at segment "user" in server (map[string]interface {} of len 3)`},
+		{func() { gp.MustInt("server.tags.2") }, `cannot resolve server.tags.2: index out of range at segment "2" in server.tags ([]interface {} of len 2)`},
+		{func() { gp.MustInt("server.host") }, "cannot cast string of path server.host to int64"},
+		{func() { gp.MustFloats("ratio") }, "cannot cast float64 of path ratio to []float64"},
+		{func() { gp.MustBool("server[0") }, "unclosed bracket at offset 6 in path server[0"},
+		{func() { gp.MustMapString("debug") }, "cannot cast bool of path debug to map[string]interface {}"},
+	}
+	for _, expect := range expects {
+		assert.Equal(t, expect.expect, mustPanicMessage(expect.fn))
+	}
+}
+
+func mustPanicMessage(fn func()) (msg string) {
+	defer func() {
+		if err, ok := recover().(error); ok {
+			msg = err.Error()
+		}
+	}()
+	fn()
+	return ""
+}
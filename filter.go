@@ -0,0 +1,601 @@
+package gpath
+
+import (
+	"fmt"
+	"github.com/ukautz/cast"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Predicate decides whether a value matches a filter. Predicates can be used within paths
+// (`users[?(@.active == true)].name`), parsed from expressions with ParsePredicate or build
+// programmatically with Eq, Gt, Exists, And, .. and used with GPath.Filter.
+type Predicate interface {
+	Match(value interface{}) bool
+}
+
+// PredicateFunc implements Predicate with a plain function
+type PredicateFunc func(value interface{}) bool
+
+// Match calls the function
+func (f PredicateFunc) Match(value interface{}) bool {
+	return f(value)
+}
+
+// Filter returns all children of the value of the path (elements of a slice, values of a map or fields
+// of a struct) matching the predicate. The path itself can contain wildcards and filters.
+func (gp *GPath) Filter(path string, predicate Predicate) []Match {
+	segments, err := gp.parse(path, false)
+	if err != nil {
+		return nil
+	}
+	return gp.queryPath(appendSegment(segments, filterSegment(predicate, "")))
+}
+
+// Eq returns a predicate matching values, which have a value at path that equals value. The path is
+// relative to the matched value, use "" or "@" for the value itself. Comparison follows the cast rules:
+// if either side is a number, both are compared as float, if either is a bool, both are compared as
+// bool, otherwise both are compared as string.
+func Eq(path string, value interface{}) Predicate {
+	return newComparison(path, "==", value)
+}
+
+// Ne returns a predicate matching values, which do not match Eq
+func Ne(path string, value interface{}) Predicate {
+	return newComparison(path, "!=", value)
+}
+
+// Lt returns a predicate matching values, which have a value at path that is lower than value. Numbers
+// are compared as float, strings lexically.
+func Lt(path string, value interface{}) Predicate {
+	return newComparison(path, "<", value)
+}
+
+// Le returns a predicate matching values, which have a value at path that is lower than or equal to value
+func Le(path string, value interface{}) Predicate {
+	return newComparison(path, "<=", value)
+}
+
+// Gt returns a predicate matching values, which have a value at path that is greater than value
+func Gt(path string, value interface{}) Predicate {
+	return newComparison(path, ">", value)
+}
+
+// Ge returns a predicate matching values, which have a value at path that is greater than or equal to value
+func Ge(path string, value interface{}) Predicate {
+	return newComparison(path, ">=", value)
+}
+
+// Regexp returns a predicate matching values, which have a value at path that can be casted into
+// string and matches the regular expression
+func Regexp(path string, re *regexp.Regexp) Predicate {
+	return newComparison(path, "=~", re)
+}
+
+// Exists returns a predicate matching values, which have a value at path
+func Exists(path string) Predicate {
+	segments, err := parseRelativePath(path)
+	if err != nil {
+		return never
+	}
+	return &existence{pathOperand(segments)}
+}
+
+// And returns a predicate matching values, which match all predicates
+func And(predicates ...Predicate) Predicate {
+	return &conjunction{predicates}
+}
+
+// Or returns a predicate matching values, which match any of the predicates
+func Or(predicates ...Predicate) Predicate {
+	return &disjunction{predicates}
+}
+
+// Not returns a predicate matching values, which do not match the predicate
+func Not(predicate Predicate) Predicate {
+	return &negation{predicate}
+}
+
+// never is used for predicates, which are build with malformed paths
+var never = PredicateFunc(func(interface{}) bool { return false })
+
+func newComparison(path, op string, value interface{}) Predicate {
+	segments, err := parseRelativePath(path)
+	if err != nil {
+		return never
+	}
+	return &comparison{left: pathOperand(segments), op: op, right: literalOperand{value}}
+}
+
+// operand is either side of a comparison
+type operand interface {
+	resolve(in interface{}) (interface{}, bool)
+}
+
+// pathOperand resolves to the value of a path relative to the matched value
+type pathOperand []segment
+
+func (o pathOperand) resolve(in interface{}) (interface{}, bool) {
+	if len(o) == 0 {
+		return in, true
+	}
+	return followPath(o, in)
+}
+
+// literalOperand resolves to a fixed value
+type literalOperand struct {
+	value interface{}
+}
+
+func (o literalOperand) resolve(in interface{}) (interface{}, bool) {
+	return o.value, true
+}
+
+type comparison struct {
+	left  operand
+	op    string
+	right operand
+}
+
+func (c *comparison) Match(value interface{}) bool {
+	left, lok := c.left.resolve(value)
+	right, rok := c.right.resolve(value)
+	if c.op == "!=" {
+		return !lok || !rok || !compareEqual(left, right)
+	} else if !lok || !rok {
+		return false
+	}
+	switch c.op {
+	case "==":
+		return compareEqual(left, right)
+	case "=~":
+		re, ok := right.(*regexp.Regexp)
+		str, sok := cast.CastString(left)
+		return ok && sok && re.MatchString(str)
+	}
+	cmp, ok := compareOrder(left, right)
+	if !ok {
+		return false
+	}
+	switch c.op {
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	}
+	return false
+}
+
+type existence struct {
+	path pathOperand
+}
+
+func (e *existence) Match(value interface{}) bool {
+	_, ok := e.path.resolve(value)
+	return ok
+}
+
+type conjunction struct {
+	predicates []Predicate
+}
+
+func (c *conjunction) Match(value interface{}) bool {
+	for _, p := range c.predicates {
+		if !p.Match(value) {
+			return false
+		}
+	}
+	return true
+}
+
+type disjunction struct {
+	predicates []Predicate
+}
+
+func (d *disjunction) Match(value interface{}) bool {
+	for _, p := range d.predicates {
+		if p.Match(value) {
+			return true
+		}
+	}
+	return false
+}
+
+type negation struct {
+	predicate Predicate
+}
+
+func (n *negation) Match(value interface{}) bool {
+	return !n.predicate.Match(value)
+}
+
+// compareEqual returns whether both values are equal, following the cast rules
+func compareEqual(a, b interface{}) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	ak, bk := indirect(vof(a)).Kind(), indirect(vof(b)).Kind()
+	if isNumberKind(ak) || isNumberKind(bk) {
+		af, aok := cast.CastFloat(a)
+		bf, bok := cast.CastFloat(b)
+		return aok && bok && af == bf
+	} else if ak == reflect.Bool || bk == reflect.Bool {
+		ab, aok := cast.CastBool(a)
+		bb, bok := cast.CastBool(b)
+		return aok && bok && ab == bb
+	} else if as, aok := cast.CastString(a); aok {
+		bs, bok := cast.CastString(b)
+		return bok && as == bs
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// compareOrder returns -1, 0 or 1 whether a is lower, equal or greater than b. Second return value is
+// false, if the values cannot be ordered
+func compareOrder(a, b interface{}) (int, bool) {
+	ak, bk := indirect(vof(a)).Kind(), indirect(vof(b)).Kind()
+	if isNumberKind(ak) || isNumberKind(bk) {
+		af, aok := cast.CastFloat(a)
+		bf, bok := cast.CastFloat(b)
+		if !aok || !bok {
+			return 0, false
+		} else if af < bf {
+			return -1, true
+		} else if af > bf {
+			return 1, true
+		}
+		return 0, true
+	} else if ak == reflect.String && bk == reflect.String {
+		return strings.Compare(indirect(vof(a)).String(), indirect(vof(b)).String()), true
+	}
+	return 0, false
+}
+
+func isNumberKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// parseRelativePath parses a path relative to the matched value, which can be prefixed with "@"
+func parseRelativePath(path string) ([]segment, error) {
+	if strings.HasPrefix(path, "@") {
+		path = strings.TrimPrefix(path[1:], ".")
+	}
+	if path == "" {
+		return nil, nil
+	}
+	return parsePath(path)
+}
+
+// ParsePredicate parses a filter expression. Supported are comparisons (`==`, `!=`, `<`, `<=`, `>`, `>=`)
+// of relative paths (`@.price` or just `price`) and literals (numbers, quoted strings, `true`, `false`
+// and `null`), regular expression matches (`@.name =~ /^a/i` or `@.name =~ "^a"`), existence checks
+// (`exists(@.email)` or just `@.email`), negation (`!`), conjunction (`&&`), disjunction (`||`) and
+// grouping with parentheses. Within paths, filters are written in brackets (`users[?@.age > 30]`).
+func ParsePredicate(expr string) (Predicate, error) {
+	p := &predicateParser{lexer: &predicateLexer{input: expr}}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	predicate, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	} else if p.token.kind != tokenEOF {
+		return nil, p.errorf("unexpected %q", p.token.text)
+	}
+	return predicate, nil
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenLParen
+	tokenRParen
+	tokenAnd
+	tokenOr
+	tokenNot
+	tokenOperator
+	tokenString
+	tokenNumber
+	tokenRegexp
+	tokenPath
+	tokenIdent
+)
+
+type token struct {
+	kind  tokenKind
+	text  string
+	value interface{}
+	pos   int
+}
+
+type predicateLexer struct {
+	input string
+	pos   int
+}
+
+func (l *predicateLexer) errorf(pos int, format string, args ...interface{}) error {
+	return fmt.Errorf("%s at offset %d in expression %s", fmt.Sprintf(format, args...), pos, l.input)
+}
+
+// next returns the next token of the input. Regular expressions are only allowed, if afterMatch
+func (l *predicateLexer) next(afterMatch bool) (token, error) {
+	for l.pos < len(l.input) && (l.input[l.pos] == ' ' || l.input[l.pos] == '\t') {
+		l.pos++
+	}
+	start := l.pos
+	if l.pos >= len(l.input) {
+		return token{kind: tokenEOF, pos: start}, nil
+	}
+	rest := l.input[l.pos:]
+	for _, op := range []struct {
+		text string
+		kind tokenKind
+	}{
+		{"&&", tokenAnd}, {"||", tokenOr}, {"==", tokenOperator}, {"!=", tokenOperator}, {"=~", tokenOperator},
+		{"<=", tokenOperator}, {">=", tokenOperator}, {"<", tokenOperator}, {">", tokenOperator},
+		{"!", tokenNot}, {"(", tokenLParen}, {")", tokenRParen},
+	} {
+		if strings.HasPrefix(rest, op.text) {
+			l.pos += len(op.text)
+			return token{kind: op.kind, text: op.text, pos: start}, nil
+		}
+	}
+
+	c := l.input[l.pos]
+	switch {
+	case c == '"' || c == '\'':
+		str, err := l.readQuoted(c)
+		return token{kind: tokenString, text: l.input[start:l.pos], value: str, pos: start}, err
+	case c == '/' && afterMatch:
+		str, err := l.readQuoted(c)
+		if err != nil {
+			return token{}, err
+		}
+		flags := l.pos
+		for l.pos < len(l.input) && l.input[l.pos] >= 'a' && l.input[l.pos] <= 'z' {
+			l.pos++
+		}
+		if flags < l.pos {
+			str = "(?" + l.input[flags:l.pos] + ")" + str
+		}
+		re, err := regexp.Compile(str)
+		if err != nil {
+			return token{}, l.errorf(start, "invalid regular expression: %s", err)
+		}
+		return token{kind: tokenRegexp, text: l.input[start:l.pos], value: re, pos: start}, nil
+	case (c >= '0' && c <= '9') || (c == '-' && l.pos+1 < len(l.input) && l.input[l.pos+1] >= '0' && l.input[l.pos+1] <= '9'):
+		for l.pos++; l.pos < len(l.input) && strings.IndexByte("0123456789.eE+-", l.input[l.pos]) > -1; l.pos++ {
+		}
+		text := l.input[start:l.pos]
+		if i, err := strconv.ParseInt(text, 10, 64); err == nil {
+			return token{kind: tokenNumber, text: text, value: i, pos: start}, nil
+		} else if f, err := strconv.ParseFloat(text, 64); err == nil {
+			return token{kind: tokenNumber, text: text, value: f, pos: start}, nil
+		}
+		return token{}, l.errorf(start, "invalid number %q", text)
+	}
+
+	// everything else is a path (or an identifier): read until whitespace or operator, but keep
+	// brackets and quotes within brackets together
+	depth := 0
+	for l.pos < len(l.input) {
+		c := l.input[l.pos]
+		if c == '\\' {
+			l.pos += 2
+			continue
+		} else if depth > 0 && (c == '"' || c == '\'') {
+			if _, err := l.readQuoted(c); err != nil {
+				return token{}, err
+			}
+			continue
+		} else if c == '[' {
+			depth++
+		} else if c == ']' {
+			depth--
+		} else if depth == 0 && strings.IndexByte(" \t()=!<>&|", c) > -1 {
+			break
+		}
+		l.pos++
+	}
+	if l.pos > len(l.input) {
+		l.pos = len(l.input)
+	}
+	text := l.input[start:l.pos]
+	if text == "" {
+		l.pos++
+		return token{}, l.errorf(start, "unexpected %q", c)
+	}
+	switch text {
+	case "true", "false", "null", "exists":
+		return token{kind: tokenIdent, text: text, pos: start}, nil
+	}
+	return token{kind: tokenPath, text: text, pos: start}, nil
+}
+
+// readQuoted reads a string enclosed by quote with backslash escapes and returns it unescaped. For
+// regular expressions, only the quote can be escaped
+func (l *predicateLexer) readQuoted(quote byte) (string, error) {
+	start := l.pos
+	buf := []byte{}
+	for l.pos++; l.pos < len(l.input) && l.input[l.pos] != quote; l.pos++ {
+		if l.input[l.pos] == '\\' && l.pos+1 < len(l.input) {
+			if quote != '/' || l.input[l.pos+1] == '/' {
+				l.pos++
+			}
+		}
+		buf = append(buf, l.input[l.pos])
+	}
+	if l.pos >= len(l.input) {
+		return "", l.errorf(start, "unterminated %c", quote)
+	}
+	l.pos++
+	return string(buf), nil
+}
+
+type predicateParser struct {
+	lexer *predicateLexer
+	token token
+}
+
+func (p *predicateParser) next() error {
+	afterMatch := p.token.kind == tokenOperator && p.token.text == "=~"
+	t, err := p.lexer.next(afterMatch)
+	if err != nil {
+		return err
+	}
+	p.token = t
+	return nil
+}
+
+func (p *predicateParser) errorf(format string, args ...interface{}) error {
+	return p.lexer.errorf(p.token.pos, format, args...)
+}
+
+func (p *predicateParser) parseOr() (Predicate, error) {
+	return p.parseJunction(tokenOr, p.parseAnd, func(ps []Predicate) Predicate { return Or(ps...) })
+}
+
+func (p *predicateParser) parseAnd() (Predicate, error) {
+	return p.parseJunction(tokenAnd, p.parseUnary, func(ps []Predicate) Predicate { return And(ps...) })
+}
+
+func (p *predicateParser) parseJunction(kind tokenKind, parse func() (Predicate, error), join func([]Predicate) Predicate) (Predicate, error) {
+	first, err := parse()
+	if err != nil {
+		return nil, err
+	}
+	predicates := []Predicate{first}
+	for p.token.kind == kind {
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		predicate, err := parse()
+		if err != nil {
+			return nil, err
+		}
+		predicates = append(predicates, predicate)
+	}
+	if len(predicates) == 1 {
+		return first, nil
+	}
+	return join(predicates), nil
+}
+
+func (p *predicateParser) parseUnary() (Predicate, error) {
+	switch {
+	case p.token.kind == tokenNot:
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		predicate, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return Not(predicate), nil
+	case p.token.kind == tokenLParen:
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		predicate, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		} else if p.token.kind != tokenRParen {
+			return nil, p.errorf("expected \")\"")
+		}
+		return predicate, p.next()
+	case p.token.kind == tokenIdent && p.token.text == "exists":
+		if err := p.next(); err != nil {
+			return nil, err
+		} else if p.token.kind != tokenLParen {
+			return nil, p.errorf("expected \"(\" after exists")
+		} else if err := p.next(); err != nil {
+			return nil, err
+		}
+		path, err := p.parsePath()
+		if err != nil {
+			return nil, err
+		} else if p.token.kind != tokenRParen {
+			return nil, p.errorf("expected \")\"")
+		}
+		return &existence{path}, p.next()
+	}
+	return p.parseComparison()
+}
+
+func (p *predicateParser) parseComparison() (Predicate, error) {
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	if p.token.kind != tokenOperator {
+		if path, ok := left.(pathOperand); ok {
+			return &existence{path}, nil
+		}
+		return nil, p.errorf("expected comparison operator")
+	}
+	op := p.token.text
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	if op == "=~" && p.token.kind == tokenString {
+		re, err := regexp.Compile(p.token.value.(string))
+		if err != nil {
+			return nil, p.errorf("invalid regular expression: %s", err)
+		}
+		p.token.value = re
+		p.token.kind = tokenRegexp
+	} else if op == "=~" && p.token.kind != tokenRegexp {
+		return nil, p.errorf("expected regular expression")
+	}
+	right, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	return &comparison{left: left, op: op, right: right}, nil
+}
+
+func (p *predicateParser) parseOperand() (operand, error) {
+	t := p.token
+	switch t.kind {
+	case tokenString, tokenNumber, tokenRegexp:
+		return literalOperand{t.value}, p.next()
+	case tokenIdent:
+		switch t.text {
+		case "true":
+			return literalOperand{true}, p.next()
+		case "false":
+			return literalOperand{false}, p.next()
+		case "null":
+			return literalOperand{nil}, p.next()
+		}
+	case tokenPath:
+		return p.parsePath()
+	case tokenEOF:
+		return nil, p.errorf("unexpected end")
+	}
+	return nil, p.errorf("unexpected %q", t.text)
+}
+
+func (p *predicateParser) parsePath() (pathOperand, error) {
+	if p.token.kind != tokenPath {
+		return nil, p.errorf("expected path")
+	}
+	segments, err := parseRelativePath(p.token.text)
+	if err != nil {
+		return nil, p.errorf("invalid path %s: %s", p.token.text, err)
+	}
+	return pathOperand(segments), p.next()
+}
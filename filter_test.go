@@ -0,0 +1,126 @@
+package gpath
+
+import (
+	"github.com/stretchr/testify/assert"
+	"regexp"
+	"testing"
+)
+
+var _testFilterData = map[string]interface{}{
+	"users": []interface{}{
+		map[string]interface{}{"name": "alice", "active": true, "age": 30, "email": "alice@example.com"},
+		map[string]interface{}{"name": "bob", "active": false, "age": "25"},
+		map[string]interface{}{"name": "carol", "active": true, "age": 35.5, "email": "carol@example.org"},
+	},
+	"items": []interface{}{
+		map[string]interface{}{"id": "a", "price": 5},
+		map[string]interface{}{"id": "b", "price": 15},
+		map[string]interface{}{"id": "c", "price": 25, "tags": []string{"sale"}},
+	},
+	"tags": []string{"red", "green", "blue"},
+}
+
+func TestGPath_QueryFilter(t *testing.T) {
+	gp := New(_testFilterData)
+	expects := []struct {
+		path   string
+		expect []string
+	}{
+		{"users[?(@.active == true)].name", []string{"alice", "carol"}},
+		{"users[?@.active == false].name", []string{"bob"}},
+		{"users[?active != true].name", []string{"bob"}},
+		{"users[?@.age >= 30].name", []string{"alice", "carol"}},
+		{"users[?@.age < 30].name", []string{"bob"}},
+		{"users[?age == 25].name", []string{"bob"}},
+		{"users[?@.age > 26 && @.active == true].name", []string{"alice", "carol"}},
+		{"users[?@.age > 31 || @.name == 'bob'].name", []string{"bob", "carol"}},
+		{"users[?!(@.age > 31 || @.name == 'bob')].name", []string{"alice"}},
+		{"users[?exists(@.email)].name", []string{"alice", "carol"}},
+		{"users[?@.email].name", []string{"alice", "carol"}},
+		{"users[?!exists(@.email)].name", []string{"bob"}},
+		{`users[?@.email =~ /\.org$/].name`, []string{"carol"}},
+		{`users[?@.name =~ /^A/i].name`, []string{"alice"}},
+		{`users[?@.name =~ "^[bc]"].name`, []string{"bob", "carol"}},
+		{"users[?@.name == \"alice\"].email", []string{"alice@example.com"}},
+		{"items[?price > 10].id", []string{"b", "c"}},
+		{"items[?price > 10 && price < 20].id", []string{"b"}},
+		{"items[?@.tags[0] == 'sale'].id", []string{"c"}},
+		{"items[?@['id'] == 'a'].price", []string{"5"}},
+		{"tags[?@ != 'green']", []string{"red", "blue"}},
+		{"users[?@.age > 100].name", nil},
+		{"users[?@.other == 1].name", nil},
+	}
+	for _, expect := range expects {
+		assert.Equal(t, expect.expect, gp.QueryStrings(expect.path), "Filter %s should match", expect.path)
+	}
+
+	assert.Equal(t, []Match{{"users.1", _testFilterData["users"].([]interface{})[1]}}, gp.Query("users[?@.name == 'bob']"), "Concrete path of filter match")
+	assert.Nil(t, gp.Get("users[?@.active == true]"), "Get does not expand filters")
+	assert.Nil(t, gp.Query("users[?@.active == ]"), "Malformed filter")
+}
+
+func TestGPath_Filter(t *testing.T) {
+	gp := New(_testFilterData)
+	names := func(matches []Match) []string {
+		var res []string
+		for _, match := range matches {
+			res = append(res, New(match.Value).GetString("name"))
+		}
+		return res
+	}
+	assert.Equal(t, []string{"alice", "carol"}, names(gp.Filter("users", Eq("active", true))))
+	assert.Equal(t, []string{"bob"}, names(gp.Filter("users", Ne("@.active", true))))
+	assert.Equal(t, []string{"bob"}, names(gp.Filter("users", Lt("age", 30))))
+	assert.Equal(t, []string{"alice", "bob"}, names(gp.Filter("users", Le("age", 30))))
+	assert.Equal(t, []string{"carol"}, names(gp.Filter("users", Gt("age", 30))))
+	assert.Equal(t, []string{"alice", "carol"}, names(gp.Filter("users", Ge("age", 30))))
+	assert.Equal(t, []string{"alice", "carol"}, names(gp.Filter("users", Exists("email"))))
+	assert.Equal(t, []string{"carol"}, names(gp.Filter("users", Regexp("email", regexp.MustCompile(`\.org$`)))))
+	assert.Equal(t, []string{"alice"}, names(gp.Filter("users", And(Eq("active", true), Lt("age", 31)))))
+	assert.Equal(t, []string{"alice", "bob"}, names(gp.Filter("users", Or(Eq("name", "alice"), Eq("name", "bob")))))
+	assert.Equal(t, []string{"bob", "carol"}, names(gp.Filter("users", Not(Eq("name", "alice")))))
+	assert.Equal(t, []string{"bob"}, names(gp.Filter("users", PredicateFunc(func(v interface{}) bool {
+		return New(v).GetString("name") == "bob"
+	}))))
+	assert.Equal(t, []Match{{"tags.1", "green"}}, gp.Filter("tags", Eq("", "green")))
+	assert.Nil(t, gp.Filter("users", Eq("name[", "alice")), "malformed path never matches")
+}
+
+func TestParsePredicate(t *testing.T) {
+	expects := []struct {
+		expr   string
+		value  interface{}
+		expect bool
+	}{
+		{"@ == 1", 1, true},
+		{"@ == 1.0", "1", true},
+		{"@ == true", "true", true},
+		{"@ == null", nil, true},
+		{"@ != null", 1, true},
+		{"@ == 'a'", "a", true},
+		{"@ < 'b'", "a", true},
+		{"@ < 'b'", 1, false},
+		{"@ > -1.5", -1, true},
+		{"@.a == @.b", map[string]interface{}{"a": 1, "b": 1}, true},
+		{"@.a == @.b", map[string]interface{}{"a": 1, "b": 2}, false},
+		{"@.a == @.c", map[string]interface{}{"a": 1}, false},
+		{"@.a != @.c", map[string]interface{}{"a": 1}, true},
+		{"a.b", map[string]interface{}{"a": map[string]interface{}{"b": 1}}, true},
+		{"a.c", map[string]interface{}{"a": map[string]interface{}{"b": 1}}, false},
+		{`@["x y"] == 1`, map[string]interface{}{"x y": 1}, true},
+		{`@ =~ /a\/b/`, "a/b", true},
+		{"(@ > 1) && ((@ < 3))", 2, true},
+	}
+	for _, expect := range expects {
+		predicate, err := ParsePredicate(expect.expr)
+		assert.Nil(t, err, "Expression %s should parse", expect.expr)
+		if err == nil {
+			assert.Equal(t, expect.expect, predicate.Match(expect.value), "Expression %s on %v should be %v", expect.expr, expect.value, expect.expect)
+		}
+	}
+
+	for _, expr := range []string{"", "@ ==", "@ == 'a", "(@ == 1", "@ == 1)", "@ =~ 1", "@ =~ /[/", "exists @", "1", "@ == 1 &&"} {
+		_, err := ParsePredicate(expr)
+		assert.NotNil(t, err, "Expression %q should not parse", expr)
+	}
+}
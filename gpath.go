@@ -5,24 +5,42 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
-	"strconv"
-	"strings"
 )
 
-// GPath provides path based access to map or slice data structures in Go. Additionally type conversion
+// GPath provides path based access to map, slice or struct data structures in Go. Additionally type conversion
 // helper methods are provided, helping to work with user input.
 type GPath struct {
 	source     interface{}
 	traversals *cache
+	syntax     syntax
+	containers containers
 }
 
 var vof = reflect.ValueOf
 
-// New creates new GPath instance for arbitrary map or slice instances
-func New(from interface{}) *GPath {
+// New creates new GPath instance for arbitrary map, slice or struct instances. Options can change the
+// notation of paths (see WithDialect and WithSeparator) or the containers created by SetDeep (see
+// WithContainers).
+func New(from interface{}, options ...Option) *GPath {
+	gp := &GPath{
+		source:     from,
+		traversals: newCache(map[string]interface{}{}),
+		syntax:     defaultSyntax,
+		containers: defaultContainers,
+	}
+	for _, option := range options {
+		option(gp)
+	}
+	return gp
+}
+
+// child returns a new GPath for a value within the data structure, using the same notation of paths
+func (gp *GPath) child(from interface{}) *GPath {
 	return &GPath{
 		source:     from,
 		traversals: newCache(map[string]interface{}{}),
+		syntax:     gp.syntax,
+		containers: gp.containers,
 	}
 }
 
@@ -32,10 +50,10 @@ func (gp *GPath) Has(path string) bool {
 	return has
 }
 
-// IsSlice returns bool whether path exists AND is a slice of some kind
+// IsSlice returns bool whether path exists AND is a slice of some kind (or a pointer to a slice)
 func (gp *GPath) IsSlice(path string) bool {
 	if val, has := gp.get(path); has {
-		r := vof(val)
+		r := indirect(vof(val))
 		return r.Kind() == reflect.Slice
 	}
 	return false
@@ -47,36 +65,218 @@ func (gp *GPath) Get(path string) interface{} {
 	return val
 }
 
-// Set creates or writes a new value with given path. Only child elements can be modified.
+// Set creates or writes a new value with given path. Only child elements can be modified. Within slices,
+// index -1 appends a new element and any other negative index counts from the end of the slice. Exported
+// struct fields can be written, but not created, if the struct is referenced by a pointer or embedded
+// within maps or slices.
 func (gp *GPath) Set(path string, value interface{}) error {
+	segments, err := gp.parse(path, true)
+	if err != nil {
+		return err
+	}
+	return gp.set(segments, value)
+}
+
+func (gp *GPath) set(segments []segment, value interface{}) error {
+	path := gp.format(segments)
+	if len(segments) == 0 {
+		return errors.New("cannot write to empty path")
+	} else if !isConcrete(segments) {
+		return fmt.Errorf("cannot write to %s, as it does not address a single element", path)
+	}
+	parent, err := gp.writableParent(segments, "set")
+	if err != nil {
+		return err
+	}
+	last := segments[len(segments)-1]
+	parentSegments := segments[0 : len(segments)-1]
+	ref, root := parent.ref, parent.root
+	set := false
+
+	// now:
+	// for slice -> set new value in pointer to slice
+	// for map -> just put it in there
+	// for struct -> set the field in pointer to struct
+	switch ref.Elem().Kind() {
+	case reflect.Slice:
+		idx := last.index
+		if last.kind != segmentIndex {
+			return fmt.Errorf("cannot write to \"%s\" as %s is a slice, not a map, and requires integer indices", last.key, root)
+		} else if root == "." && !parent.isref {
+			return errors.New("parent element cannot be slice. Provide either pointer to slice or slice embedded within maps")
+		}
+
+		// -1 appends, any other negative index counts from the end of the slice
+		if idx < -1 {
+			if idx += ref.Elem().Len(); idx < 0 {
+				return fmt.Errorf("index %d is out of bounds for slice %s of len %d", last.index, root, ref.Elem().Len())
+			}
+			last = indexSegment(idx)
+			path = gp.format(appendSegment(parentSegments, last))
+		}
+		if set = SliceIndexSet(ref.Interface(), idx, value, true); set {
+			if !parent.isref && root != "." {
+				return gp.set(parentSegments, ref.Elem().Interface())
+			} else if idx == -1 {
+				gp.clearBelow(parentSegments)
+				return nil
+			}
+		}
+	case reflect.Map:
+		if ref.Elem().IsNil() {
+			return fmt.Errorf("could not set %s in %s (nil map)", path, root)
+		}
+		if last.kind == segmentIndex {
+			last = keySegment(last.String())
+			path = gp.format(appendSegment(parentSegments, last))
+		}
+		set = MapKeySet(ref.Elem().Interface(), last.key, value, true)
+	case reflect.Struct:
+		if root == "." && !parent.isref {
+			return errors.New("parent element cannot be struct. Provide either pointer to struct or struct embedded within maps or slices")
+		} else if last.kind == segmentIndex {
+			last = keySegment(last.String())
+			path = gp.format(appendSegment(parentSegments, last))
+		}
+		if err := StructFieldValueSet(ref, last.key, vof(value), true); err != nil {
+			return fmt.Errorf("could not set %s in %s (%s)", path, root, err)
+		} else if !parent.isref {
+			return gp.set(parentSegments, ref.Elem().Interface())
+		}
+		set = true
+	}
+
+	// when anything was actually changed -> write in cache + clear all below cache, as it is gone
+	if set {
+		written := appendSegment(parentSegments, last)
+		if cacheable(written) {
+			gp.traversals.set(formatPath(written), value)
+			gp.clearBelow(written)
+		} else {
+			gp.clearBelow(nil)
+		}
+		return nil
+	} else {
+		return fmt.Errorf("could not set %s in %s (%s)", path, root, reflect.ValueOf(parent.value).Kind())
+	}
+}
+
+// Delete removes the element of the path: a key from a map or an element from a slice, in which case all
+// following elements are shifted. As in Set, only child elements can be removed and negative indices count
+// from the end of the slice (-1 is the last element). If the path does not exist, ErrNotFound is returned.
+func (gp *GPath) Delete(path string) error {
+	segments, err := gp.parse(path, false)
+	if err != nil {
+		return err
+	}
+	return gp.delete(segments)
+}
+
+func (gp *GPath) delete(segments []segment) error {
+	path := gp.format(segments)
+	if len(segments) == 0 {
+		return errors.New("cannot delete empty path")
+	} else if !isConcrete(segments) {
+		return fmt.Errorf("cannot delete %s, as it does not address a single element", path)
+	} else if _, has := gp.getSegments(segments); !has {
+		return ErrNotFound
+	}
+	parent, err := gp.writableParent(segments, "delete")
+	if err != nil {
+		return err
+	}
+	last := segments[len(segments)-1]
+	parentSegments := segments[0 : len(segments)-1]
+	ref, root := parent.ref, parent.root
+
+	switch ref.Elem().Kind() {
+	case reflect.Slice:
+		if root == "." && !parent.isref {
+			return errors.New("parent element cannot be slice. Provide either pointer to slice or slice embedded within maps")
+		}
+		idx := last.index
+		if idx < 0 {
+			idx += ref.Elem().Len()
+		}
+		if !parent.isref {
+			// the slice shares its backing array with the data structure, which must not change
+			// unless the shortened slice can be written back
+			ref.Elem().Set(sliceCopy(ref.Elem()))
+		}
+		if err := SliceIndexValueDelete(ref, idx); err != nil {
+			return fmt.Errorf("could not delete %s in %s (%s)", path, root, err)
+		} else if !parent.isref {
+			return gp.set(parentSegments, ref.Elem().Interface())
+		}
+
+		// all following elements are shifted, so anything cached below the slice is stale
+		if cacheable(parentSegments) {
+			gp.clearBelow(parentSegments)
+		} else {
+			gp.clearBelow(nil)
+		}
+		return nil
+	case reflect.Map:
+		if err := MapKeyValueDelete(ref.Elem(), vof(last.key)); err != nil {
+			return fmt.Errorf("could not delete %s in %s (%s)", path, root, err)
+		}
+		if cacheable(segments) {
+			gp.traversals.unset(formatPath(segments))
+			gp.clearBelow(segments)
+		} else {
+			gp.clearBelow(nil)
+		}
+		return nil
+	}
+	return fmt.Errorf("could not delete %s in %s (%s)", path, root, reflect.ValueOf(parent.value).Kind())
+}
+
+// writeParent is the parent of an element which is about to be written or deleted
+type writeParent struct {
+	// value is the parent as found in the data structure
+	value interface{}
+
+	// ref is a pointer to the parent map, slice or struct
+	ref reflect.Value
+
+	// isref is whether the parent was referenced by a pointer in the data structure. If not, a modified
+	// slice or struct must be written back into its own parent.
+	isref bool
+
+	// root is the path of the parent (in the notation of the GPath) with a leading ".", eg "." for the
+	// root or ".users"
+	root string
+}
+
+// writableParent finds the parent of the element of given concrete, non-empty segments, which is to be
+// written or deleted (action), and returns a pointer to the parent map, slice or struct
+func (gp *GPath) writableParent(segments []segment, action string) (*writeParent, error) {
 	var to interface{}
-	key := ""
+	path := gp.format(segments)
+	parentSegments := segments[0 : len(segments)-1]
 	root := ""
 
 	// find parent:
-	// path is either of root (no "."), which makes root the parent, or or below root (with "."), which
+	// path is either of root (single segment), which makes root the parent, or or below root, which
 	// makes the "path's parent" the parent. So path="foo" -> root is parent and "foo.bar" -> "foo" is parent
-	if idx := strings.LastIndex(path, "."); idx <= 0 {
+	if len(parentSegments) == 0 {
 		to = gp.source
-		key = path
 		root = "."
-	} else if parent := gp.Get(path[0:idx]); parent != nil {
+	} else if parent, _ := gp.getSegments(parentSegments); parent != nil {
 		to = parent
-		key = path[idx+1:]
-		root = "." + path[0:idx]
+		root = "." + gp.format(parentSegments)
 	} else {
-		return fmt.Errorf("parent element %s does not exist", path[0:idx])
+		return nil, fmt.Errorf("parent element %s does not exist", gp.format(parentSegments))
 	}
 
-	set := false
 	ref := vof(to)
 	refk := ref.Kind()
 	isref := true
 
 	// make parent a pointer:
-	// we want to support *map, map, *slice and slice alike. For simplification,
-	// just cast now map -> *map or slice -> *slice
-	if refk == reflect.Slice || refk == reflect.Map {
+	// we want to support *map, map, *slice, slice, *struct and struct alike. For simplification,
+	// just cast now map -> *map, slice -> *slice or struct -> *struct (a copy, for structs)
+	if refk == reflect.Slice || refk == reflect.Map || refk == reflect.Struct {
 		ptr := reflect.New(ref.Type())
 		ptr.Elem().Set(ref)
 		ref = ptr
@@ -86,39 +286,27 @@ func (gp *GPath) Set(path string, value interface{}) error {
 
 	// at this point, must be pointer || fail
 	if refk != reflect.Ptr {
-		return fmt.Errorf("could not set %s in %s (%s)", path, root, reflect.ValueOf(to).Kind())
+		return nil, fmt.Errorf("could not %s %s in %s (%s)", action, path, root, reflect.ValueOf(to).Kind())
 	}
 
-	// now:
-	// for slice -> set new value in pointer to slice
-	// for map -> just put it in there
-	switch ref.Elem().Kind() {
-	case reflect.Slice:
-		if key != "-1" && !isUInt(key) {
-			return fmt.Errorf("cannot write to \"%s\" as %s is a slice, not a map, and requires positive integer indices", key, root)
-		} else if root == "." && !isref {
-			return errors.New("parent element cannot be slice. Provide either pointer to slice or slice embedded within maps")
+	// follow pointer to pointer (or interface holding a pointer) to .. until the pointer to the actual
+	// map, slice or struct, as reading does
+	for k := ref.Elem().Kind(); k == reflect.Ptr || k == reflect.Interface; k = ref.Elem().Kind() {
+		if ref.Elem().IsNil() {
+			return nil, fmt.Errorf("could not %s %s in %s (nil pointer)", action, path, root)
 		}
-		idx, _ := strconv.Atoi(key)
-		if set = SliceIndexSet(ref.Interface(), idx, value, true); set {
-			if !isref && root != "." {
-				return gp.Set(root[1:], ref.Elem().Interface())
-			} else {
-				return nil
+		ref = ref.Elem()
+		if k == reflect.Interface {
+			if ref = ref.Elem(); ref.Kind() == reflect.Map {
+				ptr := reflect.New(ref.Type())
+				ptr.Elem().Set(ref)
+				ref = ptr
+			} else if ref.Kind() != reflect.Ptr {
+				return nil, fmt.Errorf("could not %s %s in %s (%s)", action, path, root, ref.Kind())
 			}
 		}
-	case reflect.Map:
-		set = MapKeySet(ref.Elem().Interface(), key, value, true)
-	}
-
-	// when anything was actually changed -> write in cache + clear all below cache, as it is gone
-	if set {
-		gp.traversals.set(path, value)
-		gp.traversals.clear(path + ".")
-		return nil
-	} else {
-		return fmt.Errorf("could not set %s in %s (%s)", path, root, reflect.ValueOf(to).Kind())
 	}
+	return &writeParent{value: to, ref: ref, isref: isref, root: root}, nil
 }
 
 // GetChild returns path value as *gpath.GPath (child) object, if the path value is either a Map or a Slice of any kind.
@@ -130,16 +318,16 @@ func (gp *GPath) GetChild(path string) *GPath {
 		case reflect.Slice:
 			ptr := reflect.New(ref.Type())
 			ptr.Elem().Set(ref)
-			return New(ptr.Interface())
+			return gp.child(ptr.Interface())
 		case reflect.Map:
-			return New(ref.Interface())
+			return gp.child(ref.Interface())
 		case reflect.Ptr:
-			elem := ref.Elem()
+			elem := indirect(ref)
 			switch elemk := elem.Kind(); elemk {
 			case reflect.Slice:
-				return New(ref.Interface())
+				return gp.child(ref.Interface())
 			case reflect.Map:
-				return New(ref.Interface())
+				return gp.child(ref.Interface())
 			}
 		}
 	}
@@ -147,48 +335,118 @@ func (gp *GPath) GetChild(path string) *GPath {
 }
 
 func (gp *GPath) get(path string) (interface{}, bool) {
+	segments, err := gp.parse(path, false)
+	if err != nil {
+		return nil, false
+	}
+	return gp.getSegments(segments)
+}
+
+func (gp *GPath) getSegments(segments []segment) (interface{}, bool) {
+	if len(segments) == 0 {
+		return gp.source, true
+	} else if !cacheable(segments) {
+		return followPath(segments, gp.source)
+	}
+	return gp.lookup(segments, formatPath(segments))
+}
+
+// lookup returns the value of cacheable segments from cache or by following them, with path being the
+// canonical notation of the segments
+func (gp *GPath) lookup(segments []segment, path string) (interface{}, bool) {
 	if val, has := gp.traversals.get(path); has {
 		return val, true
-	} else if val, has = followPath(path, gp.source); has {
+	} else if val, has = followPath(segments, gp.source); has {
 		gp.traversals.set(path, val)
 		return val, true
 	} else {
-		gp.traversals.set(path, nil)
 		return nil, false
 	}
 }
 
-func getNext(idx string, in interface{}) (interface{}, bool) {
-	if isUInt(idx) {
-		i, _ := strconv.Atoi(idx)
-		return SliceIndex(in, i)
+// clearBelow removes all cached values below given path (but not of the path itself)
+func (gp *GPath) clearBelow(segments []segment) {
+	if len(segments) == 0 {
+		gp.traversals.clear("")
+		return
 	}
-	return MapKey(in, idx)
+	path := formatPath(segments)
+	gp.traversals.clear(path + ".")
+	gp.traversals.clear(path + "[")
 }
 
-func followPath(path string, in interface{}) (res interface{}, found bool) {
-	cur, next := splitPath(path)
-	for res, found = getNext(cur, in); found; res, found = getNext(cur, in) {
-		if len(next) == 0 {
-			return
+// cacheable returns whether the value of the path can be cached. Negative indices and ranges are
+// relative to the length of the slice, which would make cached values stale when the slice changes.
+func cacheable(segments []segment) bool {
+	for _, seg := range segments {
+		if (seg.kind == segmentIndex && seg.index < 0) || seg.kind == segmentRange {
+			return false
 		}
-		in = res
-		cur = next[0]
-		next = next[1:]
 	}
-	return
+	return true
 }
 
-func isUInt(word string) bool {
-	for _, c := range word {
-		if c < '0' || c > '9' {
+// isConcrete returns whether the path addresses a single element by keys and indices only
+func isConcrete(segments []segment) bool {
+	for _, seg := range segments {
+		if seg.kind != segmentKey && seg.kind != segmentIndex {
 			return false
 		}
 	}
-	return word != ""
+	return true
+}
+
+func getNext(seg segment, in interface{}) (interface{}, bool) {
+	var v *reflect.Value
+	ref := indirect(vof(in))
+	switch seg.kind {
+	case segmentIndex:
+		idx := seg.index
+		if idx < 0 && ref.Kind() == reflect.Slice {
+			idx += ref.Len()
+		}
+		v = SliceIndexValue(ref, idx)
+	case segmentRange:
+		if ref.Kind() == reflect.Slice {
+			start, end := seg.bounds(ref.Len())
+			// cap the range, so that appending to it cannot overwrite the following elements
+			sub := ref.Slice3(start, end, end)
+			v = &sub
+		}
+	case segmentKey:
+		if ref.Kind() == reflect.Struct {
+			v = StructFieldValue(ref, seg.key)
+		} else {
+			v = MapKeyValue(ref, vof(seg.key))
+		}
+	}
+	if v == nil {
+		return nil, false
+	}
+	return (*v).Interface(), true
+}
+
+// indirect follows pointers and interfaces until a value of any other kind is reached. The returned
+// value is invalid, if a nil pointer or nil interface was encountered on the way
+func indirect(ref reflect.Value) reflect.Value {
+	for ref.Kind() == reflect.Ptr || ref.Kind() == reflect.Interface {
+		if ref.IsNil() {
+			return reflect.Value{}
+		}
+		ref = ref.Elem()
+	}
+	return ref
 }
 
-func splitPath(path string) (string, []string) {
-	p := strings.Split(path, ".")
-	return p[0], p[1:]
+func followPath(segments []segment, in interface{}) (res interface{}, found bool) {
+	cur, next := segments[0], segments[1:]
+	for res, found = getNext(cur, in); found; res, found = getNext(cur, in) {
+		if len(next) == 0 {
+			return
+		}
+		in = res
+		cur = next[0]
+		next = next[1:]
+	}
+	return
 }
@@ -38,3 +38,47 @@ func (gp *GPath) GetBools(path string, convertSingle ...bool) []bool {
 	}
 	return nil
 }
+
+// GetBoolE works as GetBool, but returns ErrNotFound if the path does not exist and a *CastError if the
+// value cannot be casted into bool
+func (gp *GPath) GetBoolE(path string) (bool, error) {
+	val, err := gp.getE(path)
+	if err != nil {
+		return false, err
+	} else if bval, ok := cast.CastBool(val); ok {
+		return bval, nil
+	}
+	return false, castError(path, val, false)
+}
+
+// GetBoolsE works as GetBools, but returns ErrNotFound if the path does not exist and a *CastError if the
+// value cannot be casted into []bool
+func (gp *GPath) GetBoolsE(path string, convertSingle ...bool) ([]bool, error) {
+	val, err := gp.getE(path)
+	if err != nil {
+		return nil, err
+	} else if res := cast.CastBools(val); res != nil {
+		return res, nil
+	} else if len(convertSingle) > 0 && convertSingle[0] {
+		if bval, ok := cast.CastBool(val); ok {
+			return []bool{bval}, nil
+		}
+	}
+	return nil, castError(path, val, []bool{})
+}
+
+// MustBool works as GetBoolE, but panics if the path does not exist or the value cannot be casted into
+// bool. It is meant for initializations, in which missing values are fatal.
+func (gp *GPath) MustBool(path string) bool {
+	val, err := gp.GetBoolE(path)
+	gp.mustSucceed(path, err)
+	return val
+}
+
+// MustBools works as GetBoolsE, but panics if the path does not exist or the value cannot be casted into
+// []bool
+func (gp *GPath) MustBools(path string, convertSingle ...bool) []bool {
+	val, err := gp.GetBoolsE(path, convertSingle...)
+	gp.mustSucceed(path, err)
+	return val
+}
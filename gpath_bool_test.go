@@ -32,7 +32,7 @@ func TestGPath_IsBool(t *testing.T) {
 		{"complex.inner.2", true},
 		{"other", false},
 		{"mixed-ok.3", false},
-		{"mixed-nok.-1", false},
+		{"mixed-nok.-1", true},
 		{"mixed-nok.a", false},
 		{"complex.other", false},
 		{"complex.inner.4", false},
@@ -72,7 +72,7 @@ func TestGPath_GetBool(t *testing.T) {
 		{"complex.inner.2", true},
 		{"other", false},
 		{"mixed-ok.3", false},
-		{"mixed-nok.-1", false},
+		{"mixed-nok.-1", true},
 		{"mixed-nok.a", false},
 		{"complex.other", false},
 		{"complex.inner.4", false},
@@ -115,7 +115,7 @@ func TestGPath_GetBools(t *testing.T) {
 		{"complex.inner.2", nil, []bool{true}},
 		{"other", nil, nil},
 		{"mixed-ok.3", nil, nil},
-		{"mixed-nok.-1", nil, nil},
+		{"mixed-nok.-1", nil, []bool{true}},
 		{"mixed-nok.a", nil, nil},
 		{"complex.other", nil, nil},
 		{"complex.inner.4", nil, nil},
@@ -129,3 +129,31 @@ func TestGPath_GetBools(t *testing.T) {
 		assert.Equal(t, expect.expect_loose, valLoose, "Loose value from from path %s should be %###v", expect.path, expect.expect_loose)
 	}
 }
+
+func TestGPath_GetBoolE(t *testing.T) {
+	gp := _newGPath()
+	expects := []struct {
+		path   string
+		expect bool
+		err    error
+	}{
+		{"int", true, nil},
+		{"mixed-ok.1", true, nil},
+		{"string", false, &CastError{"string", "string", "bool"}},
+		{"complex", false, &CastError{"complex", "map[string]interface {}", "bool"}},
+		{"other", false, ErrNotFound},
+	}
+	for _, expect := range expects {
+		val, err := gp.GetBoolE(expect.path)
+		assert.Equal(t, expect.expect, val, "Path %s should be %v", expect.path, expect.expect)
+		assert.Equal(t, expect.err, err, "Error of path %s", expect.path)
+	}
+
+	vals, err := gp.GetBoolsE("ints")
+	assert.Nil(t, err)
+	assert.Equal(t, []bool{true, true, true}, vals)
+	_, err = gp.GetBoolsE("strings")
+	assert.Equal(t, &CastError{"strings", "[]string", "[]bool"}, err)
+	_, err = gp.GetBoolsE("other")
+	assert.Equal(t, ErrNotFound, err)
+}
@@ -0,0 +1,72 @@
+package gpath
+
+import (
+	"github.com/ukautz/cast"
+	"time"
+)
+
+// IsDuration returns bool whether path exists AND can be cast to time.Duration (eg time.Duration, string("1m30s")
+// or int(1000) (=1µs))
+func (gp *GPath) IsDuration(path string) bool {
+	if val, has := gp.get(path); has {
+		_, ok := castDuration(val)
+		return ok
+	}
+	return false
+}
+
+// GetDuration returns the value of the path as time.Duration, if it is a time.Duration, a string which can be
+// parsed by time.ParseDuration or a number of nanoseconds
+func (gp *GPath) GetDuration(path string, fallback ...time.Duration) time.Duration {
+	if val, has := gp.get(path); has {
+		if dval, ok := castDuration(val); ok {
+			return dval
+		}
+	}
+	if len(fallback) > 0 {
+		return fallback[0]
+	}
+	return 0
+}
+
+// GetDurationE works as GetDuration, but returns ErrNotFound if the path does not exist and a *CastError if the
+// value cannot be casted into time.Duration
+func (gp *GPath) GetDurationE(path string) (time.Duration, error) {
+	val, err := gp.getE(path)
+	if err != nil {
+		return 0, err
+	} else if dval, ok := castDuration(val); ok {
+		return dval, nil
+	}
+	return 0, castError(path, val, time.Duration(0))
+}
+
+// MustDuration works as GetDurationE, but panics if the path does not exist or the value cannot be casted into
+// time.Duration
+func (gp *GPath) MustDuration(path string) time.Duration {
+	val, err := gp.GetDurationE(path)
+	gp.mustSucceed(path, err)
+	return val
+}
+
+// castDuration casts time.Duration, strings in the format of time.ParseDuration and numbers (of nanoseconds)
+func castDuration(val interface{}) (time.Duration, bool) {
+	switch dval := val.(type) {
+	case time.Duration:
+		return dval, true
+	case *time.Duration:
+		if dval != nil {
+			return *dval, true
+		}
+		return 0, false
+	case string:
+		d, err := time.ParseDuration(dval)
+		return d, err == nil
+	case bool:
+		return 0, false
+	}
+	if ival, ok := cast.CastInt(val); ok {
+		return time.Duration(ival), true
+	}
+	return 0, false
+}
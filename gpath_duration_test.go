@@ -0,0 +1,53 @@
+package gpath
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestGPath_GetDuration(t *testing.T) {
+	timeout := 5 * time.Second
+	gp := New(map[string]interface{}{
+		"duration": time.Minute,
+		"pointer":  &timeout,
+		"string":   "1m30s",
+		"int":      1000,
+		"invalid":  "soon",
+		"bool":     true,
+		"list":     []interface{}{"1s"},
+	})
+	expects := []struct {
+		path     string
+		expect   time.Duration
+		fallback bool
+	}{
+		{"duration", time.Minute, false},
+		{"pointer", 5 * time.Second, false},
+		{"string", 90 * time.Second, false},
+		{"int", time.Microsecond, false},
+		{"list.0", time.Second, false},
+		{"invalid", 0, true},
+		{"bool", 0, true},
+		{"list", 0, true},
+		{"other", 0, true},
+	}
+	for _, expect := range expects {
+		assert.Equal(t, expect.expect, gp.GetDuration(expect.path), "Path %s should be %s", expect.path, expect.expect)
+		assert.Equal(t, !expect.fallback, gp.IsDuration(expect.path), "Path %s is a duration", expect.path)
+		if expect.fallback {
+			assert.Equal(t, time.Hour, gp.GetDuration(expect.path, time.Hour), "Path %s should fallback", expect.path)
+		}
+	}
+
+	val, err := gp.GetDurationE("string")
+	assert.Nil(t, err)
+	assert.Equal(t, 90*time.Second, val)
+	_, err = gp.GetDurationE("invalid")
+	assert.Equal(t, &CastError{"invalid", "string", "time.Duration"}, err)
+	_, err = gp.GetDurationE("other")
+	assert.Equal(t, ErrNotFound, err)
+
+	assert.Equal(t, time.Minute, gp.MustDuration("duration"))
+	assert.Panics(t, func() { gp.MustDuration("invalid") })
+}
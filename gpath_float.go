@@ -38,3 +38,47 @@ func (gp *GPath) GetFloats(path string, convertSingle ...bool) []float64 {
 	}
 	return nil
 }
+
+// GetFloatE works as GetFloat, but returns ErrNotFound if the path does not exist and a *CastError if the
+// value cannot be casted into float64
+func (gp *GPath) GetFloatE(path string) (float64, error) {
+	val, err := gp.getE(path)
+	if err != nil {
+		return 0, err
+	} else if fval, ok := cast.CastFloat(val); ok {
+		return fval, nil
+	}
+	return 0, castError(path, val, float64(0))
+}
+
+// GetFloatsE works as GetFloats, but returns ErrNotFound if the path does not exist and a *CastError if the
+// value cannot be casted into []float64
+func (gp *GPath) GetFloatsE(path string, convertSingle ...bool) ([]float64, error) {
+	val, err := gp.getE(path)
+	if err != nil {
+		return nil, err
+	} else if res := cast.CastFloats(val); res != nil {
+		return res, nil
+	} else if len(convertSingle) > 0 && convertSingle[0] {
+		if fval, ok := cast.CastFloat(val); ok {
+			return []float64{fval}, nil
+		}
+	}
+	return nil, castError(path, val, []float64{})
+}
+
+// MustFloat works as GetFloatE, but panics if the path does not exist or the value cannot be casted into
+// float64. It is meant for initializations, in which missing values are fatal.
+func (gp *GPath) MustFloat(path string) float64 {
+	val, err := gp.GetFloatE(path)
+	gp.mustSucceed(path, err)
+	return val
+}
+
+// MustFloats works as GetFloatsE, but panics if the path does not exist or the value cannot be casted into
+// []float64
+func (gp *GPath) MustFloats(path string, convertSingle ...bool) []float64 {
+	val, err := gp.GetFloatsE(path, convertSingle...)
+	gp.mustSucceed(path, err)
+	return val
+}
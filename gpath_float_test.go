@@ -32,7 +32,7 @@ func TestGPath_IsFloat(t *testing.T) {
 		{"complex.inner.2", true},
 		{"other", false},
 		{"mixed-ok.3", false},
-		{"mixed-nok.-1", false},
+		{"mixed-nok.-1", true},
 		{"mixed-nok.a", false},
 		{"complex.other", false},
 		{"complex.inner.4", false},
@@ -73,7 +73,7 @@ func TestGPath_GetFloat(t *testing.T) {
 		{"complex.inner.2", 12.5, false},
 		{"other", 0, true},
 		{"mixed-ok.3", 0, true},
-		{"mixed-nok.-1", 0, true},
+		{"mixed-nok.-1", 3.5, false},
 		{"mixed-nok.a", 0, true},
 		{"complex.other", 0, true},
 		{"complex.inner.4", 0, true},
@@ -120,7 +120,7 @@ func TestGPath_GetFloats(t *testing.T) {
 		{"complex.inner.2", nil, []float64{12.5}},
 		{"other", nil, nil},
 		{"mixed-ok.3", nil, nil},
-		{"mixed-nok.-1", nil, nil},
+		{"mixed-nok.-1", nil, []float64{3.5}},
 		{"mixed-nok.a", nil, nil},
 		{"complex.other", nil, nil},
 		{"complex.inner.4", nil, nil},
@@ -134,3 +134,32 @@ func TestGPath_GetFloats(t *testing.T) {
 		assert.Equal(t, expect.expect_loose, valLoose, "Loose value from from path %s should be %###v", expect.path, expect.expect_loose)
 	}
 }
+
+func TestGPath_GetFloatE(t *testing.T) {
+	gp := _newGPath()
+	expects := []struct {
+		path   string
+		expect float64
+		err    error
+	}{
+		{"float", 12.5, nil},
+		{"int", 123, nil},
+		{"mixed-ok.0", 1.5, nil},
+		{"mixed-nok.0", 0, &CastError{"mixed-nok.0", "string", "float64"}},
+		{"floats", 0, &CastError{"floats", "[]float32", "float64"}},
+		{"other", 0, ErrNotFound},
+	}
+	for _, expect := range expects {
+		val, err := gp.GetFloatE(expect.path)
+		assert.Equal(t, expect.expect, val, "Path %s should be %###v", expect.path, expect.expect)
+		assert.Equal(t, expect.err, err, "Error of path %s", expect.path)
+	}
+
+	vals, err := gp.GetFloatsE("mixed-ok")
+	assert.Nil(t, err)
+	assert.Equal(t, []float64{1.5, 2, 3.5}, vals)
+	_, err = gp.GetFloatsE("complex.inner")
+	assert.Equal(t, &CastError{"complex.inner", "[]interface {}", "[]float64"}, err)
+	_, err = gp.GetFloatsE("other", true)
+	assert.Equal(t, ErrNotFound, err)
+}
@@ -40,3 +40,47 @@ func (gp *GPath) GetInts(path string, convertSingle ...bool) []int64 {
 	}
 	return nil
 }
+
+// GetIntE works as GetInt, but returns ErrNotFound if the path does not exist and a *CastError if the
+// value cannot be casted into int64
+func (gp *GPath) GetIntE(path string) (int64, error) {
+	val, err := gp.getE(path)
+	if err != nil {
+		return 0, err
+	} else if ival, ok := cast.CastInt(val); ok {
+		return ival, nil
+	}
+	return 0, castError(path, val, int64(0))
+}
+
+// GetIntsE works as GetInts, but returns ErrNotFound if the path does not exist and a *CastError if the
+// value cannot be casted into []int64
+func (gp *GPath) GetIntsE(path string, convertSingle ...bool) ([]int64, error) {
+	val, err := gp.getE(path)
+	if err != nil {
+		return nil, err
+	} else if res := cast.CastInts(val); res != nil {
+		return res, nil
+	} else if len(convertSingle) > 0 && convertSingle[0] {
+		if ival, ok := cast.CastInt(val); ok {
+			return []int64{ival}, nil
+		}
+	}
+	return nil, castError(path, val, []int64{})
+}
+
+// MustInt works as GetIntE, but panics if the path does not exist or the value cannot be casted into
+// int64. It is meant for initializations, in which missing values are fatal.
+func (gp *GPath) MustInt(path string) int64 {
+	val, err := gp.GetIntE(path)
+	gp.mustSucceed(path, err)
+	return val
+}
+
+// MustInts works as GetIntsE, but panics if the path does not exist or the value cannot be casted into
+// []int64
+func (gp *GPath) MustInts(path string, convertSingle ...bool) []int64 {
+	val, err := gp.GetIntsE(path, convertSingle...)
+	gp.mustSucceed(path, err)
+	return val
+}
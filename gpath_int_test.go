@@ -32,7 +32,7 @@ func TestGPath_IsInt(t *testing.T) {
 		{"complex.inner.2", true},
 		{"other", false},
 		{"mixed-ok.3", false},
-		{"mixed-nok.-1", false},
+		{"mixed-nok.-1", true},
 		{"mixed-nok.a", false},
 		{"complex.other", false},
 		{"complex.inner.4", false},
@@ -73,7 +73,7 @@ func TestGPath_GetInt(t *testing.T) {
 		{"complex.inner.2", 12, false},
 		{"other", 0, true},
 		{"mixed-ok.3", 0, true},
-		{"mixed-nok.-1", 0, true},
+		{"mixed-nok.-1", 3, false},
 		{"mixed-nok.a", 0, true},
 		{"complex.other", 0, true},
 		{"complex.inner.4", 0, true},
@@ -120,7 +120,7 @@ func TestGPath_GetInts(t *testing.T) {
 		{"complex.inner.2", nil, []int64{12}},
 		{"other", nil, nil},
 		{"mixed-ok.3", nil, nil},
-		{"mixed-nok.-1", nil, nil},
+		{"mixed-nok.-1", nil, []int64{3}},
 		{"mixed-nok.a", nil, nil},
 		{"complex.other", nil, nil},
 		{"complex.inner.4", nil, nil},
@@ -134,3 +134,36 @@ func TestGPath_GetInts(t *testing.T) {
 		assert.Equal(t, expect.expect_loose, valLoose, "Loose value from from path %s should be %###v", expect.path, expect.expect_loose)
 	}
 }
+
+func TestGPath_GetIntE(t *testing.T) {
+	gp := _newGPath()
+	expects := []struct {
+		path   string
+		expect int64
+		err    error
+	}{
+		{"int", 123, nil},
+		{"float", 12, nil},
+		{"mixed-ok.0", 1, nil},
+		{"string", 0, &CastError{"string", "string", "int64"}},
+		{"ints", 0, &CastError{"ints", "[]int", "int64"}},
+		{"other", 0, ErrNotFound},
+		{"mixed-nok.a", 0, ErrNotFound},
+	}
+	for _, expect := range expects {
+		val, err := gp.GetIntE(expect.path)
+		assert.Equal(t, expect.expect, val, "Path %s should be %###v", expect.path, expect.expect)
+		assert.Equal(t, expect.err, err, "Error of path %s", expect.path)
+	}
+
+	vals, err := gp.GetIntsE("floats")
+	assert.Nil(t, err)
+	assert.Equal(t, []int64{3, 4, 5}, vals)
+	_, err = gp.GetIntsE("mixed-nok")
+	assert.Equal(t, &CastError{"mixed-nok", "[]interface {}", "[]int64"}, err)
+	vals, err = gp.GetIntsE("int", true)
+	assert.Nil(t, err)
+	assert.Equal(t, []int64{123}, vals)
+	_, err = gp.GetIntsE("other")
+	assert.Equal(t, ErrNotFound, err)
+}
@@ -5,10 +5,10 @@ import (
 	"reflect"
 )
 
-// IsMap returns bool whether path exists AND is a map of some kind
+// IsMap returns bool whether path exists AND is a map of some kind (or a pointer to a map)
 func (gp *GPath) IsMap(path string) bool {
 	if val, has := gp.get(path); has {
-		r := vof(val)
+		r := indirect(vof(val))
 		return r.Kind() == reflect.Map
 	}
 	return false
@@ -93,3 +93,123 @@ func (gp *GPath) GetMapStringBool(path string, fallback ...map[string]bool) map[
 	}
 	return nil
 }
+
+// GetMapE works as GetMap, but returns ErrNotFound if the path does not exist and a *CastError if the
+// value cannot be casted into map[interface{}]interface{}
+func (gp *GPath) GetMapE(path string) (map[interface{}]interface{}, error) {
+	val, err := gp.getE(path)
+	if err != nil {
+		return nil, err
+	} else if mval, ok := cast.CastMap(val); ok {
+		return mval, nil
+	}
+	return nil, castError(path, val, map[interface{}]interface{}{})
+}
+
+// GetMapStringE works as GetMapString, but returns ErrNotFound if the path does not exist and a *CastError if the
+// value cannot be casted into map[string]interface{}
+func (gp *GPath) GetMapStringE(path string) (map[string]interface{}, error) {
+	val, err := gp.getE(path)
+	if err != nil {
+		return nil, err
+	} else if mval, ok := cast.CastMapString(val); ok {
+		return mval, nil
+	}
+	return nil, castError(path, val, map[string]interface{}{})
+}
+
+// GetMapStringStringE works as GetMapStringString, but returns ErrNotFound if the path does not exist and a *CastError if the
+// value cannot be casted into map[string]string
+func (gp *GPath) GetMapStringStringE(path string) (map[string]string, error) {
+	val, err := gp.getE(path)
+	if err != nil {
+		return nil, err
+	} else if mval, ok := cast.CastMapStringString(val); ok {
+		return mval, nil
+	}
+	return nil, castError(path, val, map[string]string{})
+}
+
+// GetMapStringIntE works as GetMapStringInt, but returns ErrNotFound if the path does not exist and a *CastError if the
+// value cannot be casted into map[string]int64
+func (gp *GPath) GetMapStringIntE(path string) (map[string]int64, error) {
+	val, err := gp.getE(path)
+	if err != nil {
+		return nil, err
+	} else if mval, ok := cast.CastMapStringInt(val); ok {
+		return mval, nil
+	}
+	return nil, castError(path, val, map[string]int64{})
+}
+
+// GetMapStringFloatE works as GetMapStringFloat, but returns ErrNotFound if the path does not exist and a *CastError if the
+// value cannot be casted into map[string]float64
+func (gp *GPath) GetMapStringFloatE(path string) (map[string]float64, error) {
+	val, err := gp.getE(path)
+	if err != nil {
+		return nil, err
+	} else if mval, ok := cast.CastMapStringFloat(val); ok {
+		return mval, nil
+	}
+	return nil, castError(path, val, map[string]float64{})
+}
+
+// GetMapStringBoolE works as GetMapStringBool, but returns ErrNotFound if the path does not exist and a *CastError if the
+// value cannot be casted into map[string]bool
+func (gp *GPath) GetMapStringBoolE(path string) (map[string]bool, error) {
+	val, err := gp.getE(path)
+	if err != nil {
+		return nil, err
+	} else if mval, ok := cast.CastMapStringBool(val); ok {
+		return mval, nil
+	}
+	return nil, castError(path, val, map[string]bool{})
+}
+
+// MustMap works as GetMapE, but panics if the path does not exist or the value cannot be casted into
+// map[interface{}]interface{}
+func (gp *GPath) MustMap(path string) map[interface{}]interface{} {
+	val, err := gp.GetMapE(path)
+	gp.mustSucceed(path, err)
+	return val
+}
+
+// MustMapString works as GetMapStringE, but panics if the path does not exist or the value cannot be casted into
+// map[string]interface{}
+func (gp *GPath) MustMapString(path string) map[string]interface{} {
+	val, err := gp.GetMapStringE(path)
+	gp.mustSucceed(path, err)
+	return val
+}
+
+// MustMapStringString works as GetMapStringStringE, but panics if the path does not exist or the value cannot be casted into
+// map[string]string
+func (gp *GPath) MustMapStringString(path string) map[string]string {
+	val, err := gp.GetMapStringStringE(path)
+	gp.mustSucceed(path, err)
+	return val
+}
+
+// MustMapStringInt works as GetMapStringIntE, but panics if the path does not exist or the value cannot be casted into
+// map[string]int64
+func (gp *GPath) MustMapStringInt(path string) map[string]int64 {
+	val, err := gp.GetMapStringIntE(path)
+	gp.mustSucceed(path, err)
+	return val
+}
+
+// MustMapStringFloat works as GetMapStringFloatE, but panics if the path does not exist or the value cannot be casted into
+// map[string]float64
+func (gp *GPath) MustMapStringFloat(path string) map[string]float64 {
+	val, err := gp.GetMapStringFloatE(path)
+	gp.mustSucceed(path, err)
+	return val
+}
+
+// MustMapStringBool works as GetMapStringBoolE, but panics if the path does not exist or the value cannot be casted into
+// map[string]bool
+func (gp *GPath) MustMapStringBool(path string) map[string]bool {
+	val, err := gp.GetMapStringBoolE(path)
+	gp.mustSucceed(path, err)
+	return val
+}
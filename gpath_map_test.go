@@ -195,3 +195,33 @@ func TestGPath_GetMapStringBool(t *testing.T) {
 	assert.Nil(t, gp.GetMapStringBool("bla"))
 	assert.Equal(t, map[string]bool{"foo": false}, gp.GetMapStringBool("bla", map[string]bool{"foo": false}))
 }
+
+func TestGPath_GetMapE(t *testing.T) {
+	source := map[string]interface{}{
+		"ok":  map[string]interface{}{"foo": "bar", "num": 1},
+		"nok": []interface{}{"foo"},
+	}
+	gp := New(source)
+
+	mval, err := gp.GetMapE("ok")
+	assert.Nil(t, err)
+	assert.Equal(t, map[interface{}]interface{}{"foo": "bar", "num": 1}, mval)
+	_, err = gp.GetMapE("nok")
+	assert.Equal(t, &CastError{"nok", "[]interface {}", "map[interface {}]interface {}"}, err)
+
+	msval, err := gp.GetMapStringE("ok")
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"foo": "bar", "num": 1}, msval)
+	_, err = gp.GetMapStringE("other")
+	assert.Equal(t, ErrNotFound, err)
+
+	mssval, err := gp.GetMapStringStringE("ok")
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"foo": "bar", "num": "1"}, mssval)
+	_, err = gp.GetMapStringIntE("ok")
+	assert.Equal(t, &CastError{"ok", "map[string]interface {}", "map[string]int64"}, err)
+	_, err = gp.GetMapStringFloatE("nok")
+	assert.Equal(t, &CastError{"nok", "[]interface {}", "map[string]float64"}, err)
+	_, err = gp.GetMapStringBoolE("other")
+	assert.Equal(t, ErrNotFound, err)
+}
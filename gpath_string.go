@@ -40,3 +40,47 @@ func (gp *GPath) GetStrings(path string, convertSingle ...bool) []string {
 	}
 	return nil
 }
+
+// GetStringE works as GetString, but returns ErrNotFound if the path does not exist and a *CastError if the
+// value cannot be casted into string
+func (gp *GPath) GetStringE(path string) (string, error) {
+	val, err := gp.getE(path)
+	if err != nil {
+		return "", err
+	} else if sval, ok := cast.CastString(val); ok {
+		return sval, nil
+	}
+	return "", castError(path, val, "")
+}
+
+// GetStringsE works as GetStrings, but returns ErrNotFound if the path does not exist and a *CastError if the
+// value cannot be casted into []string
+func (gp *GPath) GetStringsE(path string, convertSingle ...bool) ([]string, error) {
+	val, err := gp.getE(path)
+	if err != nil {
+		return nil, err
+	} else if res := cast.CastStrings(val); res != nil {
+		return res, nil
+	} else if len(convertSingle) > 0 && convertSingle[0] {
+		if sval, ok := cast.CastString(val); ok {
+			return []string{sval}, nil
+		}
+	}
+	return nil, castError(path, val, []string{})
+}
+
+// MustString works as GetStringE, but panics if the path does not exist or the value cannot be casted into
+// string. It is meant for initializations, in which missing values are fatal.
+func (gp *GPath) MustString(path string) string {
+	val, err := gp.GetStringE(path)
+	gp.mustSucceed(path, err)
+	return val
+}
+
+// MustStrings works as GetStringsE, but panics if the path does not exist or the value cannot be casted into
+// []string
+func (gp *GPath) MustStrings(path string, convertSingle ...bool) []string {
+	val, err := gp.GetStringsE(path, convertSingle...)
+	gp.mustSucceed(path, err)
+	return val
+}
@@ -33,7 +33,7 @@ func TestGPath_GetString(t *testing.T) {
 		{"complex.inner.2", "12.5", false},
 		{"other", "", true},
 		{"mixed-ok.3", "", true},
-		{"mixed-nok.-1", "", true},
+		{"mixed-nok.-1", "3.5", false},
 		{"mixed-nok.a", "", true},
 		{"complex.other", "", true},
 		{"complex.inner.4", "", true},
@@ -79,7 +79,7 @@ func TestGPath_IsString(t *testing.T) {
 		{"complex.inner.2", true},
 		{"other", false},
 		{"mixed-ok.3", false},
-		{"mixed-nok.-1", false},
+		{"mixed-nok.-1", true},
 		{"mixed-nok.a", false},
 		{"complex.other", false},
 		{"complex.inner.4", false},
@@ -120,7 +120,7 @@ func TestGPath_GetStrings(t *testing.T) {
 		{"complex.inner.2", nil, []string{"12.5"}},
 		{"other", nil, nil},
 		{"mixed-ok.3", nil, nil},
-		{"mixed-nok.-1", nil, nil},
+		{"mixed-nok.-1", nil, []string{"3.5"}},
 		{"mixed-nok.a", nil, nil},
 		{"complex.other", nil, nil},
 		{"complex.inner.4", nil, nil},
@@ -134,3 +134,36 @@ func TestGPath_GetStrings(t *testing.T) {
 		assert.Equal(t, expect.expect_loose, valLoose, "Loose value from from path %s should be %###v", expect.path, expect.expect_loose)
 	}
 }
+
+func TestGPath_GetStringE(t *testing.T) {
+	gp := _newGPath()
+	expects := []struct {
+		path   string
+		expect string
+		err    error
+	}{
+		{"string", "bar", nil},
+		{"int", "123", nil},
+		{"mixed-nok.-1", "3.5", nil},
+		{"strings", "", &CastError{"strings", "[]string", "string"}},
+		{"complex", "", &CastError{"complex", "map[string]interface {}", "string"}},
+		{"other", "", ErrNotFound},
+		{"complex.inner.4", "", ErrNotFound},
+	}
+	for _, expect := range expects {
+		val, err := gp.GetStringE(expect.path)
+		assert.Equal(t, expect.expect, val, "Path %s should be %###v", expect.path, expect.expect)
+		assert.Equal(t, expect.err, err, "Error of path %s", expect.path)
+	}
+
+	vals, err := gp.GetStringsE("mixed-ok")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"1.5", "2", "3.5"}, vals)
+	vals, err = gp.GetStringsE("string")
+	assert.Equal(t, &CastError{"string", "string", "[]string"}, err)
+	vals, err = gp.GetStringsE("string", true)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"bar"}, vals)
+	_, err = gp.GetStringsE("other")
+	assert.Equal(t, ErrNotFound, err)
+}
@@ -55,7 +55,7 @@ func TestGPath_Has(t *testing.T) {
 		{"complex.inner.2", true},
 		{"other", false},
 		{"mixed-ok.3", false},
-		{"mixed-nok.-1", false},
+		{"mixed-nok.-1", true},
 		{"mixed-nok.a", false},
 		{"complex.other", false},
 		{"complex.inner.4", false},
@@ -135,7 +135,7 @@ func TestGPath_Get(t *testing.T) {
 		{"complex.inner.2", 12.5},
 		{"other", nil},
 		{"mixed-ok.3", nil},
-		{"mixed-nok.-1", nil},
+		{"mixed-nok.-1", float32(3.5)},
 		{"mixed-nok.a", nil},
 		{"complex.other", nil},
 		{"complex.inner.4", nil},
@@ -242,3 +242,181 @@ func TestGPath_Set(t *testing.T) {
 	gp = New("string")
 	assert.NotNil(t, gp.Set("key", "bar"), "can NOT set in scalar")
 }
+
+func TestGPath_Pointers(t *testing.T) {
+	ss := []string{"a", "b"}
+	ssp := &ss
+	mm := map[string]interface{}{"foo": "bar"}
+	var nilMap *map[string]interface{}
+	var iface interface{} = &mm
+	source := map[string]interface{}{
+		"slice-ptr":     &ss,
+		"slice-ptr-ptr": &ssp,
+		"map-ptr":       &mm,
+		"map-iface":     &iface,
+		"struct-ptr":    &testStructTLS{Cert: "cert.pem"},
+		"nil-ptr":       nilMap,
+		"nil-struct":    (*testStructTLS)(nil),
+	}
+	for _, from := range []interface{}{source, &source} {
+		gp := New(from)
+		assert.Equal(t, "b", gp.Get("slice-ptr.1"), "slice via pointer")
+		assert.Equal(t, "a", gp.Get("slice-ptr-ptr.0"), "slice via pointer to pointer")
+		assert.Equal(t, "bar", gp.Get("map-ptr.foo"), "map via pointer")
+		assert.Equal(t, "bar", gp.Get("map-iface.foo"), "map via pointer to interface")
+		assert.Equal(t, "cert.pem", gp.Get("struct-ptr.cert"), "struct via pointer")
+		assert.True(t, gp.Has("nil-ptr"), "nil pointer itself exists")
+		assert.False(t, gp.Has("nil-ptr.foo"), "nothing below nil pointer")
+		assert.False(t, gp.Has("nil-struct.cert"), "nothing below nil struct pointer")
+		assert.True(t, gp.IsSlice("slice-ptr-ptr"), "pointer to pointer to slice is slice")
+		assert.True(t, gp.IsMap("map-ptr"), "pointer to map is map")
+		assert.NotNil(t, gp.GetChild("slice-ptr-ptr"), "pointer to pointer to slice is child")
+	}
+
+	assert.Equal(t, "b", New(&ss).Get("1"), "root pointer to slice")
+	assert.Equal(t, "b", New(&ssp).Get("1"), "root pointer to pointer to slice")
+	assert.Nil(t, New(nilMap).Get("foo"), "root nil pointer")
+	assert.Equal(t, New(mm).Get("foo"), New(&mm).Get("foo"), "root pointer to map")
+
+	gp := New(source)
+	assert.Nil(t, gp.Set("map-iface.x", 1), "can set in map via pointer to interface")
+	assert.Equal(t, 1, mm["x"], "set in map behind interface")
+	assert.Nil(t, gp.Delete("map-iface.x"), "can delete in map via pointer to interface")
+	assert.NotContains(t, mm, "x")
+
+	gp = New(&ssp)
+	assert.Nil(t, gp.Set("-1", "c"), "can add to pointer to pointer to slice")
+	assert.Equal(t, []string{"a", "b", "c"}, ss, "added to slice behind pointers")
+
+	gp = New(map[string]interface{}{"nil": nilMap})
+	assert.NotNil(t, gp.Set("nil.foo", "bar"), "can NOT set in nil pointer")
+}
+
+func TestGPath_NegativeIndex(t *testing.T) {
+	events := []interface{}{"created", "updated"}
+	gp := New(map[string]interface{}{
+		"events": events,
+		"nested": []interface{}{
+			map[string]interface{}{"id": 1},
+			map[string]interface{}{"id": 2},
+		},
+	})
+	assert.Equal(t, "updated", gp.Get("events.-1"))
+	assert.Equal(t, "created", gp.Get("events[-2]"))
+	assert.False(t, gp.Has("events.-3"))
+	assert.Equal(t, int64(2), gp.GetInt("nested.-1.id"))
+
+	assert.Nil(t, gp.Set("events.-1", "deleted"), "-1 appends")
+	assert.Equal(t, "deleted", gp.Get("events.-1"), "negative index reads are not stale")
+	assert.Equal(t, []string{"created", "updated", "deleted"}, gp.GetStrings("events"))
+
+	assert.Nil(t, gp.Set("events.-2", "changed"), "other negative indices count from the end")
+	assert.Equal(t, []string{"created", "changed", "deleted"}, gp.GetStrings("events"))
+	assert.Equal(t, "changed", gp.Get("events.1"))
+	assert.NotNil(t, gp.Set("events.-5", "nope"), "negative index out of bounds")
+
+	assert.Nil(t, gp.Set("nested.-1.id", 3))
+	assert.Equal(t, int64(3), gp.GetInt("nested.1.id"))
+
+	ss := []string{"a"}
+	gp = New(&ss)
+	assert.Equal(t, "a", gp.Get("-1"))
+	assert.Nil(t, gp.Set("-1", "b"))
+	assert.Equal(t, "b", gp.Get("-1"), "root negative index reads are not stale")
+	assert.Equal(t, "b", gp.Get("1"))
+}
+
+func TestGPath_Range(t *testing.T) {
+	gp := New(map[string]interface{}{
+		"items": []string{"a", "b", "c", "d", "e"},
+		"nums":  []interface{}{1, "2", 3.5},
+		"users": []interface{}{
+			map[string]interface{}{"name": "alice"},
+			map[string]interface{}{"name": "bob"},
+			map[string]interface{}{"name": "carol"},
+		},
+	})
+	assert.Equal(t, []string{"b", "c", "d"}, gp.Get("items[1:4]"))
+	assert.Equal(t, []string{"a", "b", "c"}, gp.GetStrings("items[:3]"))
+	assert.Equal(t, []string{"d", "e"}, gp.GetStrings("items[-2:]"))
+	assert.Equal(t, []string{}, gp.GetStrings("items[4:2]"))
+	assert.Equal(t, []int64{2, 3}, gp.GetInts("nums[1:]"))
+	assert.Equal(t, "c", gp.Get("items[1:4].1"), "continue below range")
+	assert.True(t, gp.IsSlice("items[1:4]"))
+	assert.False(t, gp.Has("items.0[1:2]"), "range on non slice")
+
+	child := gp.GetChild("users[1:]")
+	assert.NotNil(t, child)
+	assert.Equal(t, "carol", child.GetString("1.name"))
+
+	sub := gp.GetChild("items[1:3]")
+	assert.Nil(t, sub.Set("-1", "X"))
+	assert.Equal(t, "X", sub.Get("2"))
+	assert.Equal(t, []string{"a", "b", "c", "d", "e"}, gp.Get("items"), "appending to range does not change the slice")
+
+	assert.Equal(t, []string{"bob", "carol"}, gp.QueryStrings("users[1:].name"), "query expands ranges")
+	assert.Equal(t, []Match{{"items.3", "d"}, {"items.4", "e"}}, gp.Query("items[-2:]"))
+	assert.NotNil(t, gp.Set("items[1:2]", "x"), "cannot set range")
+	assert.NotNil(t, gp.Set("users[1:].0.name", "x"), "cannot set below range")
+}
+
+func TestGPath_Delete(t *testing.T) {
+	items := []interface{}{"x", "y"}
+	data := map[string]interface{}{
+		"user": map[string]interface{}{
+			"name":     "alice",
+			"password": "secret",
+			"roles":    []interface{}{"admin", "dev", "ops"},
+		},
+		"items": &items,
+		"list":  []string{"a", "b", "c"},
+		"tls":   testStructTLS{Cert: "a.pem"},
+	}
+	gp := New(data)
+
+	assert.Equal(t, "secret", gp.Get("user.password"), "value is cached")
+	assert.Nil(t, gp.Delete("user.password"))
+	assert.False(t, gp.Has("user.password"), "cached value is gone")
+	assert.Equal(t, map[string]interface{}{"name": "alice", "roles": []interface{}{"admin", "dev", "ops"}}, gp.Get("user"))
+
+	assert.Equal(t, "dev", gp.Get("user.roles.1"))
+	assert.Equal(t, "ops", gp.Get("user.roles.2"))
+	assert.Nil(t, gp.Delete("user.roles.1"))
+	assert.Equal(t, "ops", gp.Get("user.roles.1"), "following elements are shifted")
+	assert.False(t, gp.Has("user.roles.2"), "cached values below slice are gone")
+	assert.Equal(t, []interface{}{"admin", "ops"}, data["user"].(map[string]interface{})["roles"], "shortened slice is written back")
+
+	assert.Nil(t, gp.Delete("user.roles.-1"), "negative index counts from end")
+	assert.Equal(t, []string{"admin"}, gp.GetStrings("user.roles"))
+	assert.Nil(t, gp.Delete("list[0]"))
+	assert.Equal(t, []string{"b", "c"}, gp.Get("list"))
+	assert.Nil(t, gp.Delete("items.0"), "pointer to slice is modified in place")
+	assert.Equal(t, []interface{}{"y"}, items)
+
+	assert.Equal(t, map[string]interface{}{"name": "alice", "roles": []interface{}{"admin"}}, gp.Get("user"))
+	assert.Nil(t, gp.Delete("user"))
+	assert.False(t, gp.Has("user.name"), "cached descendants are gone")
+	assert.False(t, gp.Has("user"))
+
+	assert.Equal(t, ErrNotFound, gp.Delete("user"), "cannot delete what does not exist")
+	assert.Equal(t, ErrNotFound, gp.Delete("list.5"))
+	assert.EqualError(t, gp.delete(nil), "cannot delete empty path")
+	assert.EqualError(t, gp.Delete("list.*"), "cannot delete list.*, as it does not address a single element")
+	assert.EqualError(t, gp.Delete("tls.Cert"), "could not delete tls.Cert in .tls (struct)")
+	assert.IsType(t, &PathError{}, gp.Delete("list[0"))
+
+	root := []interface{}{1, 2}
+	assert.NotNil(t, New(root).Delete("0"), "root slice must be given as pointer")
+	assert.Nil(t, New(&root).Delete("0"))
+	assert.Equal(t, []interface{}{2}, root)
+
+	pipeline := testStructPipeline{Steps: []string{"a", "b", "c"}}
+	assert.EqualError(t, New(pipeline).Delete("Steps.0"), "parent element cannot be struct. Provide either pointer to struct or struct embedded within maps or slices")
+	assert.Equal(t, []string{"a", "b", "c"}, pipeline.Steps, "slice in struct is unchanged, if it cannot be written back")
+	assert.Nil(t, New(&pipeline).Delete("Steps.0"), "slice in struct is written back into field")
+	assert.Equal(t, []string{"b", "c"}, pipeline.Steps)
+
+	pgp := New(map[string]interface{}{"a": map[string]interface{}{"b/c": 1, "d": 2}}, WithDialect(Pointer))
+	assert.Nil(t, pgp.Delete("/a/b~1c"), "paths in notation of GPath")
+	assert.Equal(t, map[string]interface{}{"d": 2}, pgp.Get("/a"))
+}
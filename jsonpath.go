@@ -0,0 +1,398 @@
+package gpath
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// JSONPath is a compiled JSONPath (RFC 9535) query, which can be run against any Go value that New
+// accepts: maps with string keys and structs are objects, slices and arrays are arrays, pointers and
+// interfaces are followed transparently.
+type JSONPath struct {
+	expr     string
+	segments []jpSegment
+}
+
+// JSONPathMatch is a single node of the result of a JSONPath query
+type JSONPathMatch struct {
+	Match
+
+	// Location is the normalized path of the node (eg `$['users'][0]`), as defined in RFC 9535
+	Location string
+}
+
+// CompileJSONPath parses a JSONPath query, returning an error if it is not well-formed or not
+// well-typed
+func CompileJSONPath(expr string) (*JSONPath, error) {
+	p := &jpParser{input: expr}
+	segments, err := p.parseQuery()
+	if err != nil {
+		return nil, err
+	}
+	return &JSONPath{expr: expr, segments: segments}, nil
+}
+
+// MustCompileJSONPath works as CompileJSONPath but panics if the query cannot be compiled
+func MustCompileJSONPath(expr string) *JSONPath {
+	jp, err := CompileJSONPath(expr)
+	if err != nil {
+		panic(err)
+	}
+	return jp
+}
+
+// String returns the query as it was compiled
+func (jp *JSONPath) String() string {
+	return jp.expr
+}
+
+// Query returns all nodes of the value matching the query, in the order defined by RFC 9535. Members
+// of maps are visited in sorted key order, fields of structs in declaration order.
+func (jp *JSONPath) Query(from interface{}) []JSONPathMatch {
+	return jp.query(from, formatPath)
+}
+
+// query returns all matching nodes, with paths formatted by given function
+func (jp *JSONPath) query(from interface{}, format func([]segment) string) []JSONPathMatch {
+	nodes := jpEvalSegments(jp.segments, []jpNode{{value: from}}, from)
+	if len(nodes) == 0 {
+		return nil
+	}
+	matches := make([]JSONPathMatch, len(nodes))
+	for i, node := range nodes {
+		matches[i] = JSONPathMatch{
+			Match:    Match{Path: format(node.path), Value: node.value},
+			Location: normalizedPath(node.path),
+		}
+	}
+	return matches
+}
+
+// JSONPath runs the JSONPath (RFC 9535) query against the source and returns all matching nodes, with
+// paths in the notation of the GPath. An error is returned, if the query cannot be compiled.
+func (gp *GPath) JSONPath(expr string) ([]JSONPathMatch, error) {
+	jp, err := CompileJSONPath(expr)
+	if err != nil {
+		return nil, err
+	}
+	return jp.query(gp.source, gp.format), nil
+}
+
+// jpNode is a value together with its location in the queried value
+type jpNode struct {
+	path  []segment
+	value interface{}
+}
+
+type jpSegment struct {
+	descendant bool
+	selectors  []jpSelector
+}
+
+type jpSelectorKind int
+
+const (
+	jpSelectName jpSelectorKind = iota
+	jpSelectWildcard
+	jpSelectIndex
+	jpSelectSlice
+	jpSelectFilter
+)
+
+type jpSelector struct {
+	kind   jpSelectorKind
+	name   string
+	index  int
+	slice  [3]*int
+	filter jpLogical
+}
+
+func jpEvalSegments(segments []jpSegment, nodes []jpNode, root interface{}) []jpNode {
+	for _, seg := range segments {
+		next := []jpNode{}
+		for _, node := range nodes {
+			if seg.descendant {
+				jpDescend(node, map[uintptr]bool{}, func(n jpNode) {
+					next = append(next, jpSelect(seg.selectors, n, root)...)
+				})
+			} else {
+				next = append(next, jpSelect(seg.selectors, node, root)...)
+			}
+		}
+		nodes = next
+	}
+	return nodes
+}
+
+// jpDescend calls visit for the node and all its descendants, parents before children
+func jpDescend(node jpNode, visiting map[uintptr]bool, visit func(jpNode)) {
+	ref := vof(node.value)
+	if k := ref.Kind(); k == reflect.Ptr || k == reflect.Map {
+		if visiting[ref.Pointer()] {
+			return
+		}
+		visiting[ref.Pointer()] = true
+		defer delete(visiting, ref.Pointer())
+	}
+	visit(node)
+	for _, c := range jpChildren(node) {
+		jpDescend(c, visiting, visit)
+	}
+}
+
+func jpSelect(selectors []jpSelector, node jpNode, root interface{}) []jpNode {
+	res := []jpNode{}
+	for _, sel := range selectors {
+		switch sel.kind {
+		case jpSelectName:
+			if jpKindOf(node.value) != jpObject {
+				continue
+			}
+			seg := keySegment(sel.name)
+			if val, ok := getNext(seg, node.value); ok {
+				res = append(res, jpNode{appendSegment(node.path, seg), val})
+			}
+		case jpSelectWildcard:
+			res = append(res, jpChildren(node)...)
+		case jpSelectIndex:
+			ref := indirect(vof(node.value))
+			if k := ref.Kind(); k != reflect.Slice && k != reflect.Array {
+				continue
+			}
+			idx := sel.index
+			if idx < 0 {
+				idx += ref.Len()
+			}
+			if idx >= 0 && idx < ref.Len() {
+				res = append(res, jpNode{appendSegment(node.path, indexSegment(idx)), ref.Index(idx).Interface()})
+			}
+		case jpSelectSlice:
+			ref := indirect(vof(node.value))
+			if k := ref.Kind(); k != reflect.Slice && k != reflect.Array {
+				continue
+			}
+			for _, idx := range jpSliceIndices(sel.slice, ref.Len()) {
+				res = append(res, jpNode{appendSegment(node.path, indexSegment(idx)), ref.Index(idx).Interface()})
+			}
+		case jpSelectFilter:
+			for _, c := range jpChildren(node) {
+				if sel.filter.eval(&jpContext{root: root, current: c.value}) {
+					res = append(res, c)
+				}
+			}
+		}
+	}
+	return res
+}
+
+// jpChildren returns the members of an object or the elements of an array
+func jpChildren(node jpNode) []jpNode {
+	ref := indirect(vof(node.value))
+	if ref.Kind() == reflect.Array {
+		res := make([]jpNode, ref.Len())
+		for i := range res {
+			res[i] = jpNode{appendSegment(node.path, indexSegment(i)), ref.Index(i).Interface()}
+		}
+		return res
+	}
+	list := children(node.value)
+	res := make([]jpNode, len(list))
+	for i, c := range list {
+		res[i] = jpNode{appendSegment(node.path, c.segment), c.value}
+	}
+	return res
+}
+
+// jpSliceIndices returns the indices selected by a slice selector (start:end:step) as defined in RFC 9535
+func jpSliceIndices(slice [3]*int, length int) []int {
+	step := 1
+	if slice[2] != nil {
+		step = *slice[2]
+	}
+	if step == 0 {
+		return nil
+	}
+	start, end := 0, length
+	if step < 0 {
+		start, end = length-1, -length-1
+	}
+	if slice[0] != nil {
+		start = *slice[0]
+	}
+	if slice[1] != nil {
+		end = *slice[1]
+	}
+	normalize := func(i int) int {
+		if i >= 0 {
+			return i
+		}
+		return length + i
+	}
+	clamp := func(i, lower, upper int) int {
+		if i < lower {
+			return lower
+		} else if i > upper {
+			return upper
+		}
+		return i
+	}
+	start, end = normalize(start), normalize(end)
+	res := []int{}
+	if step > 0 {
+		lower, upper := clamp(start, 0, length), clamp(end, 0, length)
+		for i := lower; i < upper; i += step {
+			res = append(res, i)
+		}
+	} else {
+		upper, lower := clamp(start, -1, length-1), clamp(end, -1, length-1)
+		for i := upper; lower < i; i += step {
+			res = append(res, i)
+		}
+	}
+	return res
+}
+
+// jpKind is the kind of a value in the JSON data model
+type jpKind int
+
+const (
+	jpOther jpKind = iota
+	jpNull
+	jpBool
+	jpNumber
+	jpString
+	jpArray
+	jpObject
+)
+
+// jpKindOf returns the JSON kind of a Go value
+func jpKindOf(v interface{}) jpKind {
+	if v == nil {
+		return jpNull
+	}
+	ref := vof(v)
+	for ref.Kind() == reflect.Ptr || ref.Kind() == reflect.Interface {
+		if ref.IsNil() {
+			return jpNull
+		}
+		ref = ref.Elem()
+	}
+	switch ref.Kind() {
+	case reflect.Bool:
+		return jpBool
+	case reflect.String:
+		return jpString
+	case reflect.Slice:
+		if ref.IsNil() {
+			return jpNull
+		}
+		return jpArray
+	case reflect.Array:
+		return jpArray
+	case reflect.Map:
+		if ref.IsNil() {
+			return jpNull
+		}
+		return jpObject
+	case reflect.Struct:
+		return jpObject
+	}
+	if isNumberKind(ref.Kind()) {
+		return jpNumber
+	}
+	return jpOther
+}
+
+// jpNumberOf returns the numeric value of a number
+func jpNumberOf(v interface{}) float64 {
+	ref := indirect(vof(v))
+	switch ref.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(ref.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(ref.Uint())
+	}
+	return ref.Float()
+}
+
+// jpEqual returns whether both values are equal in the JSON data model
+func jpEqual(a, b interface{}) bool {
+	ak, bk := jpKindOf(a), jpKindOf(b)
+	if ak != bk {
+		return false
+	}
+	switch ak {
+	case jpNull:
+		return true
+	case jpBool:
+		return indirect(vof(a)).Bool() == indirect(vof(b)).Bool()
+	case jpNumber:
+		return jpNumberOf(a) == jpNumberOf(b)
+	case jpString:
+		return indirect(vof(a)).String() == indirect(vof(b)).String()
+	case jpArray:
+		ar, br := indirect(vof(a)), indirect(vof(b))
+		if ar.Len() != br.Len() {
+			return false
+		}
+		for i := 0; i < ar.Len(); i++ {
+			if !jpEqual(ar.Index(i).Interface(), br.Index(i).Interface()) {
+				return false
+			}
+		}
+		return true
+	case jpObject:
+		am, bm := children(a), children(b)
+		if len(am) != len(bm) {
+			return false
+		}
+		for i := range am {
+			if am[i].segment.key != bm[i].segment.key || !jpEqual(am[i].value, bm[i].value) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// normalizedPath returns the normalized path (RFC 9535, section 2.7) of given concrete segments
+func normalizedPath(segments []segment) string {
+	buf := new(bytes.Buffer)
+	buf.WriteByte('$')
+	for _, seg := range segments {
+		if seg.kind == segmentIndex {
+			buf.WriteString("[" + strconv.Itoa(seg.index) + "]")
+			continue
+		}
+		buf.WriteString("['")
+		for _, r := range seg.key {
+			switch r {
+			case '\b':
+				buf.WriteString(`\b`)
+			case '\f':
+				buf.WriteString(`\f`)
+			case '\n':
+				buf.WriteString(`\n`)
+			case '\r':
+				buf.WriteString(`\r`)
+			case '\t':
+				buf.WriteString(`\t`)
+			case '\'':
+				buf.WriteString(`\'`)
+			case '\\':
+				buf.WriteString(`\\`)
+			default:
+				if r < 0x20 {
+					fmt.Fprintf(buf, `\u%04x`, r)
+				} else {
+					buf.WriteRune(r)
+				}
+			}
+		}
+		buf.WriteString("']")
+	}
+	return buf.String()
+}
@@ -0,0 +1,357 @@
+package gpath
+
+import (
+	"bytes"
+	"regexp"
+	"sync"
+	"unicode/utf8"
+)
+
+// jpContext is the state in which a filter expression is evaluated: the root value for absolute (`$`)
+// and the current value for relative (`@`) queries
+type jpContext struct {
+	root    interface{}
+	current interface{}
+}
+
+// jpLogical is a logical expression of a filter selector
+type jpLogical interface {
+	eval(ctx *jpContext) bool
+}
+
+type jpOr []jpLogical
+
+func (e jpOr) eval(ctx *jpContext) bool {
+	for _, expr := range e {
+		if expr.eval(ctx) {
+			return true
+		}
+	}
+	return false
+}
+
+type jpAnd []jpLogical
+
+func (e jpAnd) eval(ctx *jpContext) bool {
+	for _, expr := range e {
+		if !expr.eval(ctx) {
+			return false
+		}
+	}
+	return true
+}
+
+type jpNot struct {
+	expr jpLogical
+}
+
+func (e jpNot) eval(ctx *jpContext) bool {
+	return !e.expr.eval(ctx)
+}
+
+// jpExistence is true, if the query matches at least one node
+type jpExistence struct {
+	query *jpQuery
+}
+
+func (e jpExistence) eval(ctx *jpContext) bool {
+	return len(e.query.nodes(ctx)) > 0
+}
+
+// jpFunctionTest is true, if the function returns true or at least one node
+type jpFunctionTest struct {
+	function *jpFunction
+}
+
+func (e jpFunctionTest) eval(ctx *jpContext) bool {
+	switch res := e.function.call(ctx).(type) {
+	case bool:
+		return res
+	case []jpNode:
+		return len(res) > 0
+	}
+	return false
+}
+
+type jpComparison struct {
+	op    string
+	left  jpComparable
+	right jpComparable
+}
+
+func (e jpComparison) eval(ctx *jpContext) bool {
+	left, right := e.left.value(ctx), e.right.value(ctx)
+	switch e.op {
+	case "==":
+		return left.equal(right)
+	case "!=":
+		return !left.equal(right)
+	case "<":
+		return left.less(right)
+	case "<=":
+		return left.less(right) || left.equal(right)
+	case ">":
+		return right.less(left)
+	case ">=":
+		return right.less(left) || left.equal(right)
+	}
+	return false
+}
+
+// jpValue is the value of a comparable or the special result Nothing, eg of a query matching no node
+type jpValue struct {
+	v       interface{}
+	nothing bool
+}
+
+var jpNothing = jpValue{nothing: true}
+
+// equal compares values as defined in RFC 9535, section 2.3.5.2.2: Nothing only equals Nothing
+func (a jpValue) equal(b jpValue) bool {
+	if a.nothing || b.nothing {
+		return a.nothing && b.nothing
+	}
+	return jpEqual(a.v, b.v)
+}
+
+// less is only true for two numbers or two strings, if a is less than b
+func (a jpValue) less(b jpValue) bool {
+	if a.nothing || b.nothing {
+		return false
+	}
+	ak, bk := jpKindOf(a.v), jpKindOf(b.v)
+	if ak != bk {
+		return false
+	} else if ak == jpNumber {
+		return jpNumberOf(a.v) < jpNumberOf(b.v)
+	} else if ak == jpString {
+		return indirect(vof(a.v)).String() < indirect(vof(b.v)).String()
+	}
+	return false
+}
+
+// jpComparable is a side of a comparison: a literal, a singular query or a function returning a value
+type jpComparable interface {
+	value(ctx *jpContext) jpValue
+}
+
+type jpLiteral struct {
+	v interface{}
+}
+
+func (l jpLiteral) value(ctx *jpContext) jpValue {
+	return jpValue{v: l.v}
+}
+
+// jpQuery is an absolute (`$`) or relative (`@`) query within a filter expression
+type jpQuery struct {
+	absolute bool
+	segments []jpSegment
+}
+
+func (q *jpQuery) nodes(ctx *jpContext) []jpNode {
+	start := ctx.current
+	if q.absolute {
+		start = ctx.root
+	}
+	return jpEvalSegments(q.segments, []jpNode{{value: start}}, ctx.root)
+}
+
+// value returns the value of the only node matched by a singular query, or Nothing
+func (q *jpQuery) value(ctx *jpContext) jpValue {
+	if nodes := q.nodes(ctx); len(nodes) == 1 {
+		return jpValue{v: nodes[0].value}
+	}
+	return jpNothing
+}
+
+// singular returns whether the query can match at most one node, which is the case if it consists only
+// of child segments with a single name or index selector
+func (q *jpQuery) singular() bool {
+	for _, seg := range q.segments {
+		if seg.descendant || len(seg.selectors) != 1 {
+			return false
+		} else if k := seg.selectors[0].kind; k != jpSelectName && k != jpSelectIndex {
+			return false
+		}
+	}
+	return true
+}
+
+// jpType is the type of a function parameter or result, see RFC 9535, section 2.4.1
+type jpType int
+
+const (
+	jpValueType jpType = iota
+	jpLogicalType
+	jpNodesType
+)
+
+// jpFunctionDef describes a function extension. Call receives for each parameter of ValueType a
+// jpValue, for LogicalType a bool and for NodesType a []jpNode and returns a jpValue or a bool,
+// depending on the result type.
+type jpFunctionDef struct {
+	params []jpType
+	result jpType
+	call   func(args []interface{}) interface{}
+}
+
+// jpFunctions are the function extensions defined in RFC 9535, section 2.4
+var jpFunctions = map[string]*jpFunctionDef{
+	"length": {
+		params: []jpType{jpValueType},
+		result: jpValueType,
+		call: func(args []interface{}) interface{} {
+			arg := args[0].(jpValue)
+			if arg.nothing {
+				return jpNothing
+			}
+			ref := indirect(vof(arg.v))
+			switch jpKindOf(arg.v) {
+			case jpString:
+				return jpValue{v: utf8.RuneCountInString(ref.String())}
+			case jpArray:
+				return jpValue{v: ref.Len()}
+			case jpObject:
+				return jpValue{v: len(children(arg.v))}
+			}
+			return jpNothing
+		},
+	},
+	"count": {
+		params: []jpType{jpNodesType},
+		result: jpValueType,
+		call: func(args []interface{}) interface{} {
+			return jpValue{v: len(args[0].([]jpNode))}
+		},
+	},
+	"match": {
+		params: []jpType{jpValueType, jpValueType},
+		result: jpLogicalType,
+		call: func(args []interface{}) interface{} {
+			return jpRegexpCall(args, true)
+		},
+	},
+	"search": {
+		params: []jpType{jpValueType, jpValueType},
+		result: jpLogicalType,
+		call: func(args []interface{}) interface{} {
+			return jpRegexpCall(args, false)
+		},
+	},
+	"value": {
+		params: []jpType{jpNodesType},
+		result: jpValueType,
+		call: func(args []interface{}) interface{} {
+			if nodes := args[0].([]jpNode); len(nodes) == 1 {
+				return jpValue{v: nodes[0].value}
+			}
+			return jpNothing
+		},
+	},
+}
+
+// jpArgument is an argument of a function call, of which exactly one field is set according to the
+// type of the parameter
+type jpArgument struct {
+	value   jpComparable
+	logical jpLogical
+	nodes   *jpQuery
+}
+
+type jpFunction struct {
+	name string
+	def  *jpFunctionDef
+	args []jpArgument
+}
+
+func (f *jpFunction) call(ctx *jpContext) interface{} {
+	args := make([]interface{}, len(f.args))
+	for i, arg := range f.args {
+		switch {
+		case arg.value != nil:
+			args[i] = arg.value.value(ctx)
+		case arg.logical != nil:
+			args[i] = arg.logical.eval(ctx)
+		default:
+			args[i] = arg.nodes.nodes(ctx)
+		}
+	}
+	return f.def.call(args)
+}
+
+func (f *jpFunction) value(ctx *jpContext) jpValue {
+	if res, ok := f.call(ctx).(jpValue); ok {
+		return res
+	}
+	return jpNothing
+}
+
+// jpRegexpCall implements match (full match) and search (any substring matches). Both are false if
+// either argument is not a string or the pattern is not a valid regular expression.
+func jpRegexpCall(args []interface{}, full bool) bool {
+	str, pattern := args[0].(jpValue), args[1].(jpValue)
+	if str.nothing || pattern.nothing || jpKindOf(str.v) != jpString || jpKindOf(pattern.v) != jpString {
+		return false
+	}
+	re, err := jpRegexp(indirect(vof(pattern.v)).String(), full)
+	if err != nil {
+		return false
+	}
+	return re.MatchString(indirect(vof(str.v)).String())
+}
+
+// jpRegexpCache holds compiled I-Regexp (RFC 9485) patterns (no sync.Map, to support Go < 1.9)
+var jpRegexpCache = struct {
+	data map[jpRegexpKey]*regexp.Regexp
+	mux  sync.RWMutex
+}{data: map[jpRegexpKey]*regexp.Regexp{}}
+
+type jpRegexpKey struct {
+	pattern string
+	full    bool
+}
+
+// jpRegexp compiles an I-Regexp pattern into a Go regular expression. In I-Regexp "." matches any
+// character but line feed and carriage return, and a full match is anchored at both ends.
+func jpRegexp(pattern string, full bool) (*regexp.Regexp, error) {
+	key := jpRegexpKey{pattern, full}
+	jpRegexpCache.mux.RLock()
+	re, ok := jpRegexpCache.data[key]
+	jpRegexpCache.mux.RUnlock()
+	if ok {
+		return re, nil
+	}
+
+	buf := new(bytes.Buffer)
+	inClass := false
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; {
+		case c == '\\' && i+1 < len(pattern):
+			buf.WriteByte(c)
+			i++
+			buf.WriteByte(pattern[i])
+			continue
+		case c == '[':
+			inClass = true
+		case c == ']':
+			inClass = false
+		case c == '.' && !inClass:
+			buf.WriteString(`[^\n\r]`)
+			continue
+		}
+		buf.WriteByte(pattern[i])
+	}
+	expr := buf.String()
+	if full {
+		expr = `\A(?:` + expr + `)\z`
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+	jpRegexpCache.mux.Lock()
+	jpRegexpCache.data[key] = re
+	jpRegexpCache.mux.Unlock()
+	return re, nil
+}
@@ -0,0 +1,621 @@
+package gpath
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// jpMaxInt is the largest integer allowed in indices and slices (2^53-1), see RFC 9535 section 2.1
+const jpMaxInt = 1<<53 - 1
+
+// jpParser reads a JSONPath query as specified in the ABNF grammar of RFC 9535
+type jpParser struct {
+	input string
+	pos   int
+}
+
+func (p *jpParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("%s at offset %d in JSONPath %s", fmt.Sprintf(format, args...), p.pos, p.input)
+}
+
+func (p *jpParser) peek() byte {
+	if p.pos < len(p.input) {
+		return p.input[p.pos]
+	}
+	return 0
+}
+
+func (p *jpParser) skipSpace() {
+	for p.pos < len(p.input) {
+		switch p.input[p.pos] {
+		case ' ', '\t', '\n', '\r':
+			p.pos++
+		default:
+			return
+		}
+	}
+}
+
+func (p *jpParser) expect(c byte) error {
+	if p.peek() != c {
+		if p.pos >= len(p.input) {
+			return p.errorf("expected %q but reached end", c)
+		}
+		return p.errorf("expected %q but found %q", c, p.input[p.pos])
+	}
+	p.pos++
+	return nil
+}
+
+func (p *jpParser) parseQuery() ([]jpSegment, error) {
+	if err := p.expect('$'); err != nil {
+		return nil, err
+	}
+	segments, err := p.parseSegments()
+	if err != nil {
+		return nil, err
+	} else if p.pos < len(p.input) {
+		return nil, p.errorf("unexpected %q", p.input[p.pos])
+	}
+	return segments, nil
+}
+
+// parseSegments reads child (`.name`, `[...]`) and descendant (`..name`, `..[...]`) segments, until
+// something else follows
+func (p *jpParser) parseSegments() ([]jpSegment, error) {
+	segments := []jpSegment{}
+	for {
+		start := p.pos
+		p.skipSpace()
+		var seg jpSegment
+		var err error
+		if strings.HasPrefix(p.input[p.pos:], "..") {
+			p.pos += 2
+			if p.peek() == '[' {
+				seg, err = p.parseBracket()
+			} else {
+				seg, err = p.parseShorthand()
+			}
+			seg.descendant = true
+		} else if p.peek() == '.' {
+			p.pos++
+			seg, err = p.parseShorthand()
+		} else if p.peek() == '[' {
+			seg, err = p.parseBracket()
+		} else {
+			p.pos = start
+			return segments, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		segments = append(segments, seg)
+	}
+}
+
+// parseShorthand reads a wildcard or a member name following a dot
+func (p *jpParser) parseShorthand() (jpSegment, error) {
+	if p.peek() == '*' {
+		p.pos++
+		return jpSegment{selectors: []jpSelector{{kind: jpSelectWildcard}}}, nil
+	}
+	start := p.pos
+	for p.pos < len(p.input) {
+		r, size := utf8.DecodeRuneInString(p.input[p.pos:])
+		if !(r == '_' || r >= 0x80 || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') ||
+			(p.pos > start && r >= '0' && r <= '9')) || r == utf8.RuneError {
+			break
+		}
+		p.pos += size
+	}
+	if p.pos == start {
+		return jpSegment{}, p.errorf("expected member name or wildcard")
+	}
+	return jpSegment{selectors: []jpSelector{{kind: jpSelectName, name: p.input[start:p.pos]}}}, nil
+}
+
+// parseBracket reads a comma separated list of selectors in brackets
+func (p *jpParser) parseBracket() (jpSegment, error) {
+	if err := p.expect('['); err != nil {
+		return jpSegment{}, err
+	}
+	seg := jpSegment{}
+	for {
+		p.skipSpace()
+		sel, err := p.parseSelector()
+		if err != nil {
+			return jpSegment{}, err
+		}
+		seg.selectors = append(seg.selectors, sel)
+		p.skipSpace()
+		if p.peek() == ']' {
+			p.pos++
+			return seg, nil
+		} else if err := p.expect(','); err != nil {
+			return jpSegment{}, err
+		}
+	}
+}
+
+func (p *jpParser) parseSelector() (jpSelector, error) {
+	switch c := p.peek(); {
+	case c == '\'' || c == '"':
+		name, err := p.parseString()
+		return jpSelector{kind: jpSelectName, name: name}, err
+	case c == '*':
+		p.pos++
+		return jpSelector{kind: jpSelectWildcard}, nil
+	case c == '?':
+		p.pos++
+		p.skipSpace()
+		filter, err := p.parseLogicalOr()
+		return jpSelector{kind: jpSelectFilter, filter: filter}, err
+	case c == '-' || c == ':' || (c >= '0' && c <= '9'):
+		return p.parseIndexOrSlice()
+	case c == 0:
+		return jpSelector{}, p.errorf("expected selector but reached end")
+	default:
+		return jpSelector{}, p.errorf("unexpected %q, expected selector", c)
+	}
+}
+
+// parseIndexOrSlice reads an index selector (`0`, `-1`) or a slice selector (`start:end:step`, each
+// part optional)
+func (p *jpParser) parseIndexOrSlice() (jpSelector, error) {
+	sel := jpSelector{kind: jpSelectSlice}
+	for part := 0; part < 3; part++ {
+		if part > 0 {
+			p.skipSpace()
+		}
+		if c := p.peek(); c == '-' || (c >= '0' && c <= '9') {
+			n, err := p.parseInt()
+			if err != nil {
+				return jpSelector{}, err
+			}
+			sel.slice[part] = &n
+		}
+		if part == 2 {
+			break
+		}
+		afterInt := p.pos
+		p.skipSpace()
+		if p.peek() != ':' {
+			p.pos = afterInt
+			if part == 0 {
+				if sel.slice[0] == nil {
+					return jpSelector{}, p.errorf("expected index")
+				}
+				return jpSelector{kind: jpSelectIndex, index: *sel.slice[0]}, nil
+			}
+			break
+		}
+		p.pos++
+	}
+	return sel, nil
+}
+
+// parseInt reads an integer without leading zeros, which can be negative (except for -0)
+func (p *jpParser) parseInt() (int, error) {
+	start := p.pos
+	if p.peek() == '-' {
+		p.pos++
+	}
+	digits := p.pos
+	for c := p.peek(); c >= '0' && c <= '9'; c = p.peek() {
+		p.pos++
+	}
+	word := p.input[start:p.pos]
+	if p.pos == digits {
+		return 0, p.errorf("expected digits")
+	} else if p.input[digits] == '0' && (p.pos-digits > 1 || digits > start) {
+		return 0, p.errorf("invalid integer %s", word)
+	}
+	n, err := strconv.ParseInt(word, 10, 64)
+	if err != nil || n > jpMaxInt || n < -jpMaxInt {
+		return 0, p.errorf("integer %s out of range", word)
+	}
+	return int(n), nil
+}
+
+// parseString reads a string literal in single or double quotes, resolving escape sequences
+func (p *jpParser) parseString() (string, error) {
+	quote := p.input[p.pos]
+	p.pos++
+	buf := new(bytes.Buffer)
+	for {
+		if p.pos >= len(p.input) {
+			return "", p.errorf("unterminated string")
+		}
+		c := p.input[p.pos]
+		switch {
+		case c == quote:
+			p.pos++
+			return buf.String(), nil
+		case c < 0x20:
+			return "", p.errorf("unescaped control character in string")
+		case c != '\\':
+			buf.WriteByte(c)
+			p.pos++
+			continue
+		}
+		p.pos++
+		switch esc := p.peek(); esc {
+		case 'b':
+			buf.WriteByte('\b')
+		case 'f':
+			buf.WriteByte('\f')
+		case 'n':
+			buf.WriteByte('\n')
+		case 'r':
+			buf.WriteByte('\r')
+		case 't':
+			buf.WriteByte('\t')
+		case '/', '\\', quote:
+			buf.WriteByte(esc)
+		case 'u':
+			r, err := p.parseUnicodeEscape()
+			if err != nil {
+				return "", err
+			}
+			buf.WriteRune(r)
+			continue
+		default:
+			return "", p.errorf("invalid escape sequence")
+		}
+		p.pos++
+	}
+}
+
+// parseUnicodeEscape reads a `uXXXX` escape sequence, or two of them for a surrogate pair
+func (p *jpParser) parseUnicodeEscape() (rune, error) {
+	hex := func() (rune, error) {
+		if p.pos+5 > len(p.input) {
+			return 0, p.errorf("invalid unicode escape")
+		}
+		n, err := strconv.ParseUint(p.input[p.pos+1:p.pos+5], 16, 32)
+		if err != nil {
+			return 0, p.errorf("invalid unicode escape")
+		}
+		p.pos += 5
+		return rune(n), nil
+	}
+	r, err := hex()
+	if err != nil {
+		return 0, err
+	} else if r >= 0xDC00 && r <= 0xDFFF {
+		return 0, p.errorf("unpaired low surrogate")
+	} else if r < 0xD800 || r > 0xDBFF {
+		return r, nil
+	}
+	if !strings.HasPrefix(p.input[p.pos:], `\u`) {
+		return 0, p.errorf("unpaired high surrogate")
+	}
+	p.pos++
+	low, err := hex()
+	if err != nil {
+		return 0, err
+	} else if low < 0xDC00 || low > 0xDFFF {
+		return 0, p.errorf("unpaired high surrogate")
+	}
+	return 0x10000 + (r-0xD800)<<10 + (low - 0xDC00), nil
+}
+
+func (p *jpParser) parseLogicalOr() (jpLogical, error) {
+	list := jpOr{}
+	for {
+		expr, err := p.parseLogicalAnd()
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, expr)
+		start := p.pos
+		p.skipSpace()
+		if !strings.HasPrefix(p.input[p.pos:], "||") {
+			p.pos = start
+			break
+		}
+		p.pos += 2
+		p.skipSpace()
+	}
+	if len(list) == 1 {
+		return list[0], nil
+	}
+	return list, nil
+}
+
+func (p *jpParser) parseLogicalAnd() (jpLogical, error) {
+	list := jpAnd{}
+	for {
+		expr, err := p.parseBasic()
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, expr)
+		start := p.pos
+		p.skipSpace()
+		if !strings.HasPrefix(p.input[p.pos:], "&&") {
+			p.pos = start
+			break
+		}
+		p.pos += 2
+		p.skipSpace()
+	}
+	if len(list) == 1 {
+		return list[0], nil
+	}
+	return list, nil
+}
+
+// parseBasic reads a parenthesized expression, a comparison or a test expression, the latter two
+// possibly negated
+func (p *jpParser) parseBasic() (jpLogical, error) {
+	if p.peek() == '!' {
+		p.pos++
+		p.skipSpace()
+		if p.peek() == '(' {
+			expr, err := p.parseParen()
+			return jpNot{expr}, err
+		}
+		start := p.pos
+		operand, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		test, err := operand.test()
+		if err != nil {
+			p.pos = start
+			return nil, p.errorf("%s", err)
+		}
+		return jpNot{test}, nil
+	} else if p.peek() == '(' {
+		return p.parseParen()
+	}
+
+	start := p.pos
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	end := p.pos
+	p.skipSpace()
+	op := p.parseComparisonOp()
+	if op == "" {
+		p.pos = end
+		test, err := left.test()
+		if err != nil {
+			p.pos = start
+			return nil, p.errorf("%s", err)
+		}
+		return test, nil
+	}
+	p.skipSpace()
+	rightStart := p.pos
+	right, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	cmp := jpComparison{op: op}
+	if cmp.left, err = left.comparable(); err != nil {
+		p.pos = start
+		return nil, p.errorf("%s", err)
+	} else if cmp.right, err = right.comparable(); err != nil {
+		p.pos = rightStart
+		return nil, p.errorf("%s", err)
+	}
+	return cmp, nil
+}
+
+func (p *jpParser) parseParen() (jpLogical, error) {
+	p.pos++
+	p.skipSpace()
+	expr, err := p.parseLogicalOr()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	if err := p.expect(')'); err != nil {
+		return nil, err
+	}
+	return expr, nil
+}
+
+func (p *jpParser) parseComparisonOp() string {
+	for _, op := range []string{"==", "!=", "<=", ">=", "<", ">"} {
+		if strings.HasPrefix(p.input[p.pos:], op) {
+			p.pos += len(op)
+			return op
+		}
+	}
+	return ""
+}
+
+// jpOperand is a literal, a query or a function call within a filter expression. Which of them is
+// allowed depends on where it is used, see comparable and test.
+type jpOperand struct {
+	literal  *jpLiteral
+	query    *jpQuery
+	function *jpFunction
+}
+
+// comparable returns the operand as side of a comparison, which must be a literal, a singular query or
+// a function returning a value
+func (o jpOperand) comparable() (jpComparable, error) {
+	switch {
+	case o.literal != nil:
+		return *o.literal, nil
+	case o.query != nil:
+		if !o.query.singular() {
+			return nil, fmt.Errorf("non-singular query cannot be compared")
+		}
+		return o.query, nil
+	case o.function.def.result != jpValueType:
+		return nil, fmt.Errorf("result of function %s() cannot be compared", o.function.name)
+	}
+	return o.function, nil
+}
+
+// test returns the operand as test expression, which must be a query or a function returning a logical
+// value or nodes
+func (o jpOperand) test() (jpLogical, error) {
+	switch {
+	case o.literal != nil:
+		return nil, fmt.Errorf("literal must be compared")
+	case o.query != nil:
+		return jpExistence{o.query}, nil
+	case o.function.def.result == jpValueType:
+		return nil, fmt.Errorf("result of function %s() must be compared", o.function.name)
+	}
+	return jpFunctionTest{o.function}, nil
+}
+
+func (p *jpParser) parseOperand() (jpOperand, error) {
+	switch c := p.peek(); {
+	case c == '@' || c == '$':
+		p.pos++
+		segments, err := p.parseSegments()
+		if err != nil {
+			return jpOperand{}, err
+		}
+		return jpOperand{query: &jpQuery{absolute: c == '$', segments: segments}}, nil
+	case c == '\'' || c == '"':
+		str, err := p.parseString()
+		return jpOperand{literal: &jpLiteral{str}}, err
+	case c == '-' || (c >= '0' && c <= '9'):
+		num, err := p.parseNumber()
+		return jpOperand{literal: &jpLiteral{num}}, err
+	case c >= 'a' && c <= 'z':
+		start := p.pos
+		for c := p.peek(); (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') || c == '_'; c = p.peek() {
+			p.pos++
+		}
+		name := p.input[start:p.pos]
+		if p.peek() == '(' {
+			p.pos = start
+			fn, err := p.parseFunction()
+			return jpOperand{function: fn}, err
+		}
+		switch name {
+		case "true":
+			return jpOperand{literal: &jpLiteral{true}}, nil
+		case "false":
+			return jpOperand{literal: &jpLiteral{false}}, nil
+		case "null":
+			return jpOperand{literal: &jpLiteral{nil}}, nil
+		}
+		p.pos = start
+		return jpOperand{}, p.errorf("unexpected %q", name)
+	case c == 0:
+		return jpOperand{}, p.errorf("expected expression but reached end")
+	}
+	return jpOperand{}, p.errorf("unexpected %q", p.input[p.pos])
+}
+
+// parseNumber reads a number literal, which can have a fraction and an exponent
+func (p *jpParser) parseNumber() (float64, error) {
+	start := p.pos
+	if p.peek() == '-' {
+		p.pos++
+	}
+	digits := p.pos
+	for c := p.peek(); c >= '0' && c <= '9'; c = p.peek() {
+		p.pos++
+	}
+	if p.pos == digits || (p.input[digits] == '0' && p.pos-digits > 1) {
+		p.pos = start
+		return 0, p.errorf("invalid number")
+	}
+	if p.peek() == '.' {
+		p.pos++
+		frac := p.pos
+		for c := p.peek(); c >= '0' && c <= '9'; c = p.peek() {
+			p.pos++
+		}
+		if p.pos == frac {
+			return 0, p.errorf("expected digits of fraction")
+		}
+	}
+	if c := p.peek(); c == 'e' || c == 'E' {
+		p.pos++
+		if c := p.peek(); c == '+' || c == '-' {
+			p.pos++
+		}
+		exp := p.pos
+		for c := p.peek(); c >= '0' && c <= '9'; c = p.peek() {
+			p.pos++
+		}
+		if p.pos == exp {
+			return 0, p.errorf("expected digits of exponent")
+		}
+	}
+	num, err := strconv.ParseFloat(p.input[start:p.pos], 64)
+	if err != nil {
+		p.pos = start
+		return 0, p.errorf("invalid number")
+	}
+	return num, nil
+}
+
+// parseFunction reads a function call and checks the arguments against the parameter types of the
+// function
+func (p *jpParser) parseFunction() (*jpFunction, error) {
+	start := p.pos
+	name := p.input[start : strings.IndexByte(p.input[start:], '(')+start]
+	def, ok := jpFunctions[name]
+	if !ok {
+		return nil, p.errorf("unknown function %s()", name)
+	}
+	p.pos += len(name) + 1
+	fn := &jpFunction{name: name, def: def}
+	p.skipSpace()
+	for p.peek() != ')' {
+		if len(fn.args) > 0 {
+			if err := p.expect(','); err != nil {
+				return nil, err
+			}
+			p.skipSpace()
+		}
+		if len(fn.args) >= len(def.params) {
+			return nil, p.errorf("too many arguments for function %s()", name)
+		}
+		arg, err := p.parseArgument(def.params[len(fn.args)], name)
+		if err != nil {
+			return nil, err
+		}
+		fn.args = append(fn.args, arg)
+		p.skipSpace()
+	}
+	if len(fn.args) < len(def.params) {
+		return nil, p.errorf("too few arguments for function %s()", name)
+	}
+	p.pos++
+	return fn, nil
+}
+
+// parseArgument reads a function argument of given parameter type
+func (p *jpParser) parseArgument(typ jpType, name string) (jpArgument, error) {
+	start := p.pos
+	if typ == jpLogicalType {
+		expr, err := p.parseLogicalOr()
+		return jpArgument{logical: expr}, err
+	}
+	operand, err := p.parseOperand()
+	if err != nil {
+		return jpArgument{}, err
+	}
+	if typ == jpNodesType {
+		if operand.query == nil {
+			p.pos = start
+			return jpArgument{}, p.errorf("function %s() expects a query as argument", name)
+		}
+		return jpArgument{nodes: operand.query}, nil
+	}
+	value, err := operand.comparable()
+	if err != nil {
+		p.pos = start
+		return jpArgument{}, p.errorf("function %s() expects a value as argument", name)
+	}
+	return jpArgument{value: value}, nil
+}
@@ -0,0 +1,283 @@
+package gpath
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// _testJSONPathStore is the example from RFC 9535, section 1.5
+const _testJSONPathStore = `{ "store": {
+	"book": [
+		{ "category": "reference", "author": "Nigel Rees", "title": "Sayings of the Century", "price": 8.95 },
+		{ "category": "fiction", "author": "Evelyn Waugh", "title": "Sword of Honour", "price": 12.99 },
+		{ "category": "fiction", "author": "Herman Melville", "title": "Moby Dick", "isbn": "0-553-21311-3", "price": 8.99 },
+		{ "category": "fiction", "author": "J. R. R. Tolkien", "title": "The Lord of the Rings", "isbn": "0-395-19395-8", "price": 22.99 }
+	],
+	"bicycle": { "color": "red", "price": 399 }
+}}`
+
+func testJSONPathData(t *testing.T, data string) interface{} {
+	var res interface{}
+	if err := json.Unmarshal([]byte(data), &res); err != nil {
+		t.Fatalf("Invalid test data: %s", err)
+	}
+	return res
+}
+
+func testJSONPathLocations(matches []JSONPathMatch) []string {
+	var res []string
+	for _, match := range matches {
+		res = append(res, match.Location)
+	}
+	return res
+}
+
+func TestCompileJSONPath(t *testing.T) {
+	valid := []string{
+		"$", "$.a", "$.*", "$..a", "$..*", "$..[0]", "$['a']", `$["a"]`, "$[0]", "$[-1]", "$[*]",
+		"$[1:3]", "$[::-1]", "$[ 1 : 3 : 2 ]", "$[0, 'a', *, 1:2]", "$ .a [0]", "$.ä", "$._x1",
+		"$[?@.a]", "$[?!@.a]", "$[?@.a == 1]", "$[?@.a != 'x' && @.b < 2 || @.c >= -1.5e3]",
+		"$[?(@.a == true) && !(@.b == null)]", "$[?@.a == $.b]", "$[?@ == false]", "$[?$..a]",
+		"$[?length(@.a) > 1]", "$[?count(@.*) == 2]", "$[?match(@.a, 'a.*')]", "$[?search(@.a, $.p)]",
+		"$[?value(@..a) == 1]", "$[?length(value(@..a)) == 1]", "$[?@[?@.a]]", `$['ä\'\n']`,
+		`$["😀"]`, "$[9007199254740991]", "$[?1 == 1]",
+	}
+	for _, expr := range valid {
+		_, err := CompileJSONPath(expr)
+		assert.Nil(t, err, "JSONPath %s should compile", expr)
+	}
+
+	invalid := []string{
+		"", "a", "@.a", "$.", "$..", "$.1", "$a", "$[", "$[]", "$['a'", "$[a]", "$[01]", "$[-0]", "$[1 2]",
+		"$[9007199254740992]", "$[0,]", "$['\\x']", "$['\u0001']", `$["\uDE00"]`, `$["\uD83D"]`, "$ ",
+		"$[?@.a == ]", "$[?1]", "$[?'a']", "$[?@.* == 1]", "$[?@..a == 1]", "$[?!@.a == 1]",
+		"$[?length(@.a)]", "$[?count(@.a)]", "$[?match(@.a, 'a') == true]", "$[?length(@.*) == 1]",
+		"$[?count(1) == 1]", "$[?foo(@.a)]", "$[?length() == 1]", "$[?length(@.a, @.b) == 1]",
+		"$[?(@.a]", "$[?@.a &&]", "$[?@.a == 01]", "$[?@.a == 1.]", "$[?@.a == tru]", "$[?@.a == {}]",
+	}
+	for _, expr := range invalid {
+		jp, err := CompileJSONPath(expr)
+		assert.NotNil(t, err, "JSONPath %s should not compile", expr)
+		assert.Nil(t, jp, "JSONPath %s should not compile", expr)
+	}
+
+	_, err := CompileJSONPath("$.a[?@.b == ]")
+	assert.EqualError(t, err, `unexpected ']' at offset 12 in JSONPath $.a[?@.b == ]`)
+	_, err = CompileJSONPath("$[?length(@.a)]")
+	assert.EqualError(t, err, `result of function length() must be compared at offset 3 in JSONPath $[?length(@.a)]`)
+
+	assert.Equal(t, "$..a", MustCompileJSONPath("$..a").String())
+	assert.Panics(t, func() { MustCompileJSONPath("$[") })
+}
+
+func TestJSONPath_Query(t *testing.T) {
+	data := testJSONPathData(t, _testJSONPathStore)
+	expects := []struct {
+		expr   string
+		expect []string
+	}{
+		{"$.store.book[*].author", []string{
+			"$['store']['book'][0]['author']", "$['store']['book'][1]['author']",
+			"$['store']['book'][2]['author']", "$['store']['book'][3]['author']",
+		}},
+		{"$..author", []string{
+			"$['store']['book'][0]['author']", "$['store']['book'][1]['author']",
+			"$['store']['book'][2]['author']", "$['store']['book'][3]['author']",
+		}},
+		{"$.store.*", []string{"$['store']['bicycle']", "$['store']['book']"}},
+		{"$.store..price", []string{
+			"$['store']['bicycle']['price']", "$['store']['book'][0]['price']", "$['store']['book'][1]['price']",
+			"$['store']['book'][2]['price']", "$['store']['book'][3]['price']",
+		}},
+		{"$..book[2]", []string{"$['store']['book'][2]"}},
+		{"$..book[-1]", []string{"$['store']['book'][3]"}},
+		{"$..book[0,1]", []string{"$['store']['book'][0]", "$['store']['book'][1]"}},
+		{"$..book[:2]", []string{"$['store']['book'][0]", "$['store']['book'][1]"}},
+		{"$..book[?@.isbn]", []string{"$['store']['book'][2]", "$['store']['book'][3]"}},
+		{"$..book[?@.price<10]", []string{"$['store']['book'][0]", "$['store']['book'][2]"}},
+		{"$..book[?@.price < 10 && @.category == 'fiction'].title", []string{"$['store']['book'][2]['title']"}},
+		{"$..book[?!@.isbn].price", []string{"$['store']['book'][0]['price']", "$['store']['book'][1]['price']"}},
+		{"$..book[?@.price > $.store.bicycle.price]", nil},
+		{"$.store.book[?@.price == 8.99]['title', 'author']", []string{
+			"$['store']['book'][2]['title']", "$['store']['book'][2]['author']",
+		}},
+		{"$", []string{"$"}},
+		{"$.store.book.title", nil},
+		{"$.store.bicycle[0]", nil},
+		{"$.store.bicycle.color.x", nil},
+		{"$[?@.book]", []string{"$['store']"}},
+	}
+	for _, expect := range expects {
+		matches := MustCompileJSONPath(expect.expr).Query(data)
+		assert.Equal(t, expect.expect, testJSONPathLocations(matches), "JSONPath %s should match", expect.expr)
+	}
+	assert.Len(t, MustCompileJSONPath("$..*").Query(data), 27, "Descendants of all nodes")
+}
+
+func TestJSONPath_QuerySlice(t *testing.T) {
+	data := []interface{}{"a", "b", "c", "d", "e", "f", "g"}
+	expects := []struct {
+		expr   string
+		expect []interface{}
+	}{
+		{"$[1:3]", []interface{}{"b", "c"}},
+		{"$[5:]", []interface{}{"f", "g"}},
+		{"$[1:5:2]", []interface{}{"b", "d"}},
+		{"$[5:1:-2]", []interface{}{"f", "d"}},
+		{"$[::-1]", []interface{}{"g", "f", "e", "d", "c", "b", "a"}},
+		{"$[-2:]", []interface{}{"f", "g"}},
+		{"$[-100:2]", []interface{}{"a", "b"}},
+		{"$[::0]", nil},
+		{"$[3:1]", nil},
+		{"$[0, 0]", []interface{}{"a", "a"}},
+		{"$[7]", nil},
+		{"$[-8]", nil},
+	}
+	for _, expect := range expects {
+		var values []interface{}
+		for _, match := range MustCompileJSONPath(expect.expr).Query(data) {
+			values = append(values, match.Value)
+		}
+		assert.Equal(t, expect.expect, values, "JSONPath %s should match", expect.expr)
+	}
+	assert.Len(t, MustCompileJSONPath("$[1:]").Query([3]int{1, 2, 3}), 2, "Arrays can be sliced")
+}
+
+func TestJSONPath_QueryFilter(t *testing.T) {
+	data := testJSONPathData(t, `{
+		"a": [3, 5, 1, 2, 4, 6, {"b": "j"}, {"b": "k"}, {"b": {}}, {"b": "kilo"}],
+		"o": {"p": 1, "q": 2, "r": 3, "s": 5, "t": {"u": 6}},
+		"e": "f"
+	}`)
+	expects := []struct {
+		expr   string
+		expect []string
+	}{
+		{"$.a[?@.b == 'kilo']", []string{"$['a'][9]"}},
+		{"$.a[?(@.b == 'kilo')]", []string{"$['a'][9]"}},
+		{"$.a[?@>3.5]", []string{"$['a'][1]", "$['a'][4]", "$['a'][5]"}},
+		{"$.a[?@.b]", []string{"$['a'][6]", "$['a'][7]", "$['a'][8]", "$['a'][9]"}},
+		{"$[?@.*]", []string{"$['a']", "$['o']"}},
+		{"$[?@[?@.b]]", []string{"$['a']"}},
+		{"$.o[?@<3, ?@<3]", []string{"$['o']['p']", "$['o']['q']", "$['o']['p']", "$['o']['q']"}},
+		{"$.a[?@<2 || @.b == \"k\"]", []string{"$['a'][2]", "$['a'][7]"}},
+		{"$.a[?match(@.b, '[jk]')]", []string{"$['a'][6]", "$['a'][7]"}},
+		{"$.a[?search(@.b, '[jk]')]", []string{"$['a'][6]", "$['a'][7]", "$['a'][9]"}},
+		{"$.o[?@>1 && @<4]", []string{"$['o']['q']", "$['o']['r']"}},
+		{"$.o[?@.u || @.x]", []string{"$['o']['t']"}},
+		{"$.a[?@.b == $.x]", []string{"$['a'][0]", "$['a'][1]", "$['a'][2]", "$['a'][3]", "$['a'][4]", "$['a'][5]"}},
+		{"$.a[?@ == @]", []string{
+			"$['a'][0]", "$['a'][1]", "$['a'][2]", "$['a'][3]", "$['a'][4]", "$['a'][5]",
+			"$['a'][6]", "$['a'][7]", "$['a'][8]", "$['a'][9]",
+		}},
+		{"$.a[?@ == $.a[6]]", []string{"$['a'][6]"}},
+		{"$.a[?@.b <= $.x]", []string{"$['a'][0]", "$['a'][1]", "$['a'][2]", "$['a'][3]", "$['a'][4]", "$['a'][5]"}},
+		{"$.a[?@.b < 'k']", []string{"$['a'][6]"}},
+		{"$.a[?@.b > 'k']", []string{"$['a'][9]"}},
+		{"$.a[?@.b < 1]", nil},
+		{"$.a[?@ == 3]", []string{"$['a'][0]"}},
+		{"$.a[?@ != 3]", []string{
+			"$['a'][1]", "$['a'][2]", "$['a'][3]", "$['a'][4]", "$['a'][5]",
+			"$['a'][6]", "$['a'][7]", "$['a'][8]", "$['a'][9]",
+		}},
+		{"$[?@ == 'f']", []string{"$['e']"}},
+		{"$.e[?@]", nil},
+	}
+	for _, expect := range expects {
+		matches := MustCompileJSONPath(expect.expr).Query(data)
+		assert.Equal(t, expect.expect, testJSONPathLocations(matches), "JSONPath %s should match", expect.expr)
+	}
+}
+
+func TestJSONPath_QueryFunctions(t *testing.T) {
+	data := testJSONPathData(t, `[
+		{"name": "alice", "tags": ["a", "b"], "id": "ab-1"},
+		{"name": "bob", "tags": [], "id": "b.2"},
+		{"name": "čarlie", "tags": ["c"], "id": "c\n3"},
+		{"name": "dave", "tags": {"x": 1, "y": 2}}
+	]`)
+	expects := []struct {
+		expr   string
+		expect []string
+	}{
+		{"$[?length(@.name) == 5]", []string{"$[0]"}},
+		{"$[?length(@.name) == 6]", []string{"$[2]"}},
+		{"$[?length(@.tags) == 2]", []string{"$[0]", "$[3]"}},
+		{"$[?length(@.id) == 4]", []string{"$[0]"}},
+		{"$[?length(@) == 3]", []string{"$[0]", "$[1]", "$[2]"}},
+		{"$[?length(1) == 1]", nil},
+		{"$[?count(@.tags.*) == 1]", []string{"$[2]"}},
+		{"$[?count(@..*) == 5]", []string{"$[0]"}},
+		{"$[?match(@.name, 'a.*')]", []string{"$[0]"}},
+		{"$[?match(@.name, 'b')]", nil},
+		{"$[?search(@.name, 'b')]", []string{"$[1]"}},
+		{"$[?match(@.id, '.\\\\..')]", []string{"$[1]"}},
+		{"$[?match(@.id, 'c.3')]", nil},
+		{"$[?search(@.id, '[')]", nil},
+		{"$[?match(@.tags, 'a')]", nil},
+		{"$[?value(@.tags[0]) == 'c']", []string{"$[2]"}},
+		{"$[?value(@.tags.*) == 'c']", []string{"$[2]"}},
+		{"$[?value(@..x) == 1]", []string{"$[3]"}},
+		{"$[?!search(@.name, 'a')]", []string{"$[1]"}},
+	}
+	for _, expect := range expects {
+		matches := MustCompileJSONPath(expect.expr).Query(data)
+		assert.Equal(t, expect.expect, testJSONPathLocations(matches), "JSONPath %s should match", expect.expr)
+	}
+}
+
+func TestGPath_JSONPath(t *testing.T) {
+	gp := New(_testQueryData)
+	matches, err := gp.JSONPath("$.users[?@.email].name")
+	assert.Nil(t, err)
+	assert.Equal(t, []JSONPathMatch{
+		{Match{"users.0.name", "alice"}, "$['users'][0]['name']"},
+		{Match{"users.2.name", "carol"}, "$['users'][2]['name']"},
+	}, matches)
+	for _, match := range matches {
+		assert.Equal(t, match.Value, gp.Get(match.Path), "Match path %s can be used with Get", match.Path)
+	}
+
+	matches, err = gp.JSONPath("$.servers[?@.key_file == 'a.key'].cert")
+	assert.Nil(t, err)
+	assert.Equal(t, []JSONPathMatch{{Match{"servers.0.cert", "a.pem"}, "$['servers'][0]['cert']"}}, matches)
+
+	matches, err = gp.JSONPath("$.users[?@.age >= 30].name")
+	assert.Nil(t, err)
+	assert.Len(t, matches, 2, "Numbers of any type are compared")
+
+	matches, err = New(&_testQueryData).JSONPath("$.groups[?@.size > 3]")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"$['groups']['dev']"}, testJSONPathLocations(matches), "Pointers are followed")
+
+	matches, err = gp.JSONPath("$.users[")
+	assert.NotNil(t, err)
+	assert.Nil(t, matches)
+
+	matches, err = New(map[string]interface{}{"a.b": map[string]interface{}{"it's": 1}}).JSONPath("$.*.*")
+	assert.Nil(t, err)
+	assert.Equal(t, []JSONPathMatch{{Match{`["a.b"].it's`, 1}, `$['a.b']['it\'s']`}}, matches)
+
+	cycle := map[string]interface{}{"a": 1}
+	cycle["self"] = cycle
+	matches, err = New(cycle).JSONPath("$..a")
+	assert.Nil(t, err)
+	assert.Len(t, matches, 1, "Cycles are not followed")
+}
+
+func Test_normalizedPath(t *testing.T) {
+	expects := []struct {
+		segments []segment
+		expect   string
+	}{
+		{nil, "$"},
+		{[]segment{keySegment("a"), indexSegment(1)}, "$['a'][1]"},
+		{[]segment{keySegment("it's\\")}, `$['it\'s\\']`},
+		{[]segment{keySegment("\b\f\n\r\t\x01")}, `$['\b\f\n\r\t\u0001']`},
+		{[]segment{keySegment("ä")}, "$['ä']"},
+	}
+	for _, expect := range expects {
+		assert.Equal(t, expect.expect, normalizedPath(expect.segments))
+	}
+}
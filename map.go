@@ -45,7 +45,10 @@ func MapKeyValue(m, k reflect.Value) *reflect.Value {
 	kki := m.Type().Key().Kind()
 	if len(kk) == 0 || (kki != reflect.Interface && kki != k.Kind()) {
 		return nil
-	} else if v := m.MapIndex(k); !v.IsValid() {
+	} else if kt := m.Type().Key(); kki != reflect.Interface && k.Type() != kt {
+		k = k.Convert(kt)
+	}
+	if v := m.MapIndex(k); !v.IsValid() {
 		return nil
 	} else {
 		return &v
@@ -58,32 +61,78 @@ func MapKeyValueSet(theMap, theKey, theValue reflect.Value, castFitting ...bool)
 	if theMap.Kind() != reflect.Map {
 		return fmt.Errorf("provided map is not Map kind but %s kind", theMap.Kind())
 	}
-	t := theMap.Type()
-	kk := t.Key().Kind()
-	fit := len(castFitting) > 0 && castFitting[0]
+	theKey, err := fitMapKey(theMap, theKey, castFitting...)
+	if err != nil {
+		return err
+	}
+	theValue, err = fitMapValue(theMap, theValue, castFitting...)
+	if err != nil {
+		return err
+	}
+	theMap.SetMapIndex(theKey, theValue)
+	return nil
+}
+
+// fitMapKey returns the key as it can be used in the map, casted to the kind of keys if castFitting is
+// enabled
+func fitMapKey(theMap, theKey reflect.Value, castFitting ...bool) (reflect.Value, error) {
+	kk := theMap.Type().Key().Kind()
 	if kk != reflect.Interface && kk != theKey.Kind() {
-		if fit {
+		if len(castFitting) > 0 && castFitting[0] {
 			if ref := cast.CastToValue(theKey, kk); ref != nil {
-				theKey = *ref
-			} else {
-				return fmt.Errorf("provided value is of %s kind and cannot be cast into %s kind", theKey.Kind(), kk)
+				return *ref, nil
 			}
-		} else {
-			return fmt.Errorf("provided key is of %s kind but must be %s kind", theKey.Kind(), kk)
+			return theKey, fmt.Errorf("provided value is of %s kind and cannot be cast into %s kind", theKey.Kind(), kk)
 		}
+		return theKey, fmt.Errorf("provided key is of %s kind but must be %s kind", theKey.Kind(), kk)
 	}
-	ek := t.Elem().Kind()
-	if ek != reflect.Interface && ek != theValue.Kind() {
-		if fit {
+	return theKey, nil
+}
+
+// fitMapValue returns the value as it can be stored in the map, casted to the kind of values if
+// castFitting is enabled
+func fitMapValue(theMap, theValue reflect.Value, castFitting ...bool) (reflect.Value, error) {
+	ek := theMap.Type().Elem().Kind()
+	if !theValue.IsValid() {
+		switch ek {
+		case reflect.Interface, reflect.Ptr, reflect.Map, reflect.Slice:
+			return reflect.Zero(theMap.Type().Elem()), nil
+		}
+		return theValue, fmt.Errorf("provided value is nil but must be %s kind", ek)
+	} else if ek != reflect.Interface && ek != theValue.Kind() {
+		if len(castFitting) > 0 && castFitting[0] {
 			if ref := cast.CastToValue(theValue, ek); ref != nil {
-				theValue = *ref
-			} else {
-				return fmt.Errorf("provided value is of %s kind and cannot be cast into %s kind", theValue.Kind(), ek)
+				return *ref, nil
 			}
-		} else {
-			return fmt.Errorf("provided value is of %s kind but must be %s kind", theValue.Kind(), ek)
+			return theValue, fmt.Errorf("provided value is of %s kind and cannot be cast into %s kind", theValue.Kind(), ek)
 		}
+		return theValue, fmt.Errorf("provided value is of %s kind but must be %s kind", theValue.Kind(), ek)
 	}
-	theMap.SetMapIndex(theKey, theValue)
+	return theValue, nil
+}
+
+// MapKeyDelete removes given key from given map. Returns bool whether the key existed and was removed.
+func MapKeyDelete(theMap, theKey interface{}) bool {
+	return MapKeyValueDelete(vof(theMap), vof(theKey)) == nil
+}
+
+// MapKeyValueDelete works as MapKeyDelete, but it expects reflect.Value parameters and returns specific error
+// why key could not be removed.
+func MapKeyValueDelete(theMap, theKey reflect.Value) error {
+	if theMap.Kind() != reflect.Map {
+		return fmt.Errorf("provided map is not Map kind but %s kind", theMap.Kind())
+	}
+	kt := theMap.Type().Key()
+	if kk := kt.Kind(); kk != reflect.Interface {
+		if kk != theKey.Kind() {
+			return fmt.Errorf("provided key is of %s kind but must be %s kind", theKey.Kind(), kk)
+		} else if theKey.Type() != kt {
+			theKey = theKey.Convert(kt)
+		}
+	}
+	if !theMap.MapIndex(theKey).IsValid() {
+		return fmt.Errorf("provided key %v does not exist", theKey.Interface())
+	}
+	theMap.SetMapIndex(theKey, reflect.Value{})
 	return nil
 }
@@ -123,3 +123,22 @@ func TestMapKeySet(t *testing.T) {
 
 	assert.False(t, MapKeySet("foo", "bar", 123.23, true), "Setting on non map fails")
 }
+
+func TestMapKeyDelete(t *testing.T) {
+	m := map[string]interface{}{"foo": 1, "bar": 2}
+	assert.True(t, MapKeyDelete(m, "foo"), "Removing of existing key should work")
+	assert.Equal(t, map[string]interface{}{"bar": 2}, m, "Key removed")
+	assert.False(t, MapKeyDelete(m, "foo"), "Removing of not existing key fails")
+	assert.False(t, MapKeyDelete(m, 1), "Removing of key with wrong kind fails")
+
+	type name string
+	mn := map[name]int{"a": 1}
+	assert.True(t, MapKeyDelete(mn, "a"), "Key of same kind is converted")
+	assert.Equal(t, map[name]int{}, mn)
+
+	mi := map[interface{}]interface{}{1: "a", "1": "b"}
+	assert.True(t, MapKeyDelete(mi, 1), "Interface keys are removed by exact value")
+	assert.Equal(t, map[interface{}]interface{}{"1": "b"}, mi)
+
+	assert.False(t, MapKeyDelete([]string{"a"}, "a"), "Removing from not-map (slice) fails")
+}
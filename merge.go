@@ -0,0 +1,241 @@
+package gpath
+
+import (
+	"errors"
+	"fmt"
+	"github.com/ukautz/cast"
+	"reflect"
+	"sort"
+)
+
+// MergeStrategy defines how Merge combines slices, see MergeReplace, MergeAppend, MergeByIndex and
+// MergeByKey
+type MergeStrategy struct {
+	slices sliceMerge
+	key    string
+}
+
+type sliceMerge int
+
+const (
+	sliceReplace sliceMerge = iota
+	sliceAppend
+	sliceByIndex
+	sliceByKey
+)
+
+var (
+	// MergeReplace replaces slices with the slices of the merged document
+	MergeReplace = MergeStrategy{slices: sliceReplace}
+
+	// MergeAppend appends the elements of slices of the merged document to the existing slices
+	MergeAppend = MergeStrategy{slices: sliceAppend}
+
+	// MergeByIndex merges the elements of slices with the same index recursively, additional elements
+	// are appended
+	MergeByIndex = MergeStrategy{slices: sliceByIndex}
+)
+
+// MergeByKey merges elements of slices (maps or structs), which have the same value in the field,
+// recursively. Elements without a matching counterpart are appended. Eg MergeByKey("name") merges
+// lists of services by their name.
+func MergeByKey(field string) MergeStrategy {
+	return MergeStrategy{slices: sliceByKey, key: field}
+}
+
+// Merge merges other (a map or slice, or a pointer to one) recursively into the data structure: maps
+// are merged key by key, slices according to the strategy and all other values of other replace the
+// existing ones. Where both values are scalars of different kinds, the value of other is casted into
+// the kind of the existing value (eg string "8080" into int), so the types of the data structure are
+// kept. If that is not possible, or a map or slice meets a value of another kind, an error is
+// returned and nothing is changed. Nil values in other are ignored.
+func (gp *GPath) Merge(other interface{}, strategy MergeStrategy) error {
+	dst, src := indirect(vof(gp.source)), indirect(vof(other))
+	if !src.IsValid() {
+		return nil
+	} else if !dst.IsValid() || (dst.Kind() != reflect.Map && dst.Kind() != reflect.Slice) {
+		return errors.New("can only merge into map or slice")
+	} else if dst.Kind() == reflect.Slice && vof(gp.source).Kind() != reflect.Ptr {
+		return errors.New("parent element cannot be slice. Provide either pointer to slice or slice embedded within maps")
+	}
+
+	// check everything before anything is changed
+	m := &merger{gp: gp, strategy: strategy}
+	if _, err := m.merge(gp.source, other, nil); err != nil {
+		return err
+	}
+	m.apply = true
+	if _, err := m.merge(gp.source, other, nil); err != nil {
+		return err
+	}
+	gp.clearBelow(nil)
+	return nil
+}
+
+// merger merges recursively, either checking only or applying the changes
+type merger struct {
+	gp       *GPath
+	strategy MergeStrategy
+	apply    bool
+}
+
+// merge returns the result of merging src into dst. Maps are modified in place, if applying. Slices
+// referenced by pointers are replaced in place, others are returned to be written into their parent.
+// Values of src are copied, so that the merged data structure never shares maps or slices with src.
+func (m *merger) merge(dst, src interface{}, segments []segment) (interface{}, error) {
+	dref, sref := indirect(vof(dst)), indirect(vof(src))
+	if !sref.IsValid() {
+		return dst, nil
+	} else if !dref.IsValid() || ((dref.Kind() == reflect.Map || dref.Kind() == reflect.Slice) && dref.IsNil()) {
+		return keepPointers(dst, deepCopyValue(vof(src))), nil
+	}
+
+	switch dk, sk := dref.Kind(), sref.Kind(); {
+	case dk == reflect.Map && sk == reflect.Map:
+		return dst, m.mergeMap(dref, sref, segments)
+	case dk == reflect.Slice && sk == reflect.Slice:
+		merged, err := m.mergeSlice(dref, sref, segments)
+		if err != nil {
+			return nil, err
+		} else if vof(dst).Kind() == reflect.Ptr {
+			if m.apply {
+				dref.Set(merged)
+			}
+			return dst, nil
+		}
+		return merged.Interface(), nil
+	case dk == reflect.Map || dk == reflect.Slice || sk == reflect.Map || sk == reflect.Slice:
+		return nil, m.errorf(segments, "cannot merge %s into %s", sref.Type(), dref.Type())
+	case sref.Type() == dref.Type():
+		return keepPointers(dst, deepCopyValue(sref)), nil
+	}
+	if ref := cast.CastToValue(sref, dref.Kind()); ref != nil && ref.Type().ConvertibleTo(dref.Type()) {
+		return keepPointers(dst, ref.Convert(dref.Type())), nil
+	}
+	return nil, m.errorf(segments, "cannot cast %s into %s", sref.Type(), dref.Type())
+}
+
+// keepPointers returns the value behind as many new pointers as dst is, so that eg a *T in the data
+// structure stays a *T when a T is merged into it
+func keepPointers(dst interface{}, val reflect.Value) interface{} {
+	if dst == nil {
+		return val.Interface()
+	}
+	return wrapPointers(vof(dst).Type(), val).Interface()
+}
+
+func wrapPointers(typ reflect.Type, val reflect.Value) reflect.Value {
+	if typ.Kind() != reflect.Ptr || val.Type() == typ {
+		return val
+	}
+	inner := wrapPointers(typ.Elem(), val)
+	if !inner.Type().AssignableTo(typ.Elem()) {
+		return val
+	}
+	ptr := reflect.New(typ.Elem())
+	ptr.Elem().Set(inner)
+	return ptr
+}
+
+// mergeMap merges the keys of src into dst in sorted order
+func (m *merger) mergeMap(dst, src reflect.Value, segments []segment) error {
+	keys := src.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+	})
+	for _, key := range keys {
+		path := appendSegment(segments, keySegment(fmt.Sprint(key.Interface())))
+		dkey, err := fitMapKey(dst, key, true)
+		if err != nil {
+			return m.errorf(path, "%s", err)
+		}
+		var existing interface{}
+		if v := dst.MapIndex(dkey); v.IsValid() {
+			existing = v.Interface()
+		}
+		merged, err := m.merge(existing, src.MapIndex(key).Interface(), path)
+		if err != nil {
+			return err
+		} else if merged == nil {
+			continue
+		}
+		value, err := fitMapValue(dst, vof(merged), true)
+		if err != nil {
+			return m.errorf(path, "%s", err)
+		} else if m.apply {
+			dst.SetMapIndex(dkey, value)
+		}
+	}
+	return nil
+}
+
+// mergeSlice returns a new slice of the type of dst with the elements of dst and src combined according
+// to the strategy
+func (m *merger) mergeSlice(dst, src reflect.Value, segments []segment) (reflect.Value, error) {
+	elems := []interface{}{}
+	if m.strategy.slices != sliceReplace {
+		for i := 0; i < dst.Len(); i++ {
+			elems = append(elems, dst.Index(i).Interface())
+		}
+	}
+	for i := 0; i < src.Len(); i++ {
+		elem := src.Index(i).Interface()
+		target := -1
+		switch m.strategy.slices {
+		case sliceByIndex:
+			if i < len(elems) {
+				target = i
+			}
+		case sliceByKey:
+			target = m.findByKey(elems, elem)
+		}
+		if target == -1 {
+			elems = append(elems, deepCopy(elem))
+		} else {
+			merged, err := m.merge(elems[target], elem, appendSegment(segments, indexSegment(target)))
+			if err != nil {
+				return dst, err
+			}
+			elems[target] = merged
+		}
+	}
+
+	res := reflect.MakeSlice(dst.Type(), 0, len(elems))
+	for i, elem := range elems {
+		value, err := fitSliceValue(res, vof(elem), true)
+		if err != nil {
+			return dst, m.errorf(appendSegment(segments, indexSegment(i)), "%s", err)
+		}
+		res = reflect.Append(res, value)
+	}
+	return res, nil
+}
+
+// findByKey returns the index of the element, which has the same value in the key field as elem, or -1
+func (m *merger) findByKey(elems []interface{}, elem interface{}) int {
+	seg := keySegment(m.strategy.key)
+	key, ok := getNext(seg, elem)
+	if !ok {
+		return -1
+	}
+	skey, ok := cast.CastString(key)
+	if !ok {
+		return -1
+	}
+	for i, existing := range elems {
+		if ekey, ok := getNext(seg, existing); ok {
+			if sekey, ok := cast.CastString(ekey); ok && sekey == skey {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func (m *merger) errorf(segments []segment, format string, args ...interface{}) error {
+	path := "root"
+	if len(segments) > 0 {
+		path = m.gp.format(segments)
+	}
+	return fmt.Errorf("cannot merge %s: %s", path, fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,196 @@
+package gpath
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func _testMergeDefaults() map[string]interface{} {
+	return map[string]interface{}{
+		"server": map[string]interface{}{"host": "localhost", "port": 8080, "debug": false},
+		"tags":   []interface{}{"a", "b"},
+		"ports":  []int{80},
+		"services": []interface{}{
+			map[string]interface{}{"name": "api", "replicas": 1},
+			map[string]interface{}{"name": "web", "replicas": 1},
+		},
+	}
+}
+
+func TestGPath_Merge(t *testing.T) {
+	override := map[string]interface{}{
+		"server":   map[string]interface{}{"port": "9090", "debug": "true", "tls": map[string]interface{}{"cert": "a.pem"}},
+		"tags":     []interface{}{"c"},
+		"ports":    []interface{}{"443"},
+		"services": []interface{}{map[string]interface{}{"name": "web", "replicas": 3}, map[string]interface{}{"name": "db"}},
+		"new":      "value",
+		"ignored":  nil,
+	}
+	expects := []struct {
+		strategy MergeStrategy
+		tags     interface{}
+		ports    interface{}
+		services interface{}
+	}{
+		{
+			MergeReplace,
+			[]interface{}{"c"},
+			[]int{443},
+			override["services"],
+		},
+		{
+			MergeAppend,
+			[]interface{}{"a", "b", "c"},
+			[]int{80, 443},
+			[]interface{}{
+				map[string]interface{}{"name": "api", "replicas": 1},
+				map[string]interface{}{"name": "web", "replicas": 1},
+				map[string]interface{}{"name": "web", "replicas": 3},
+				map[string]interface{}{"name": "db"},
+			},
+		},
+		{
+			MergeByIndex,
+			[]interface{}{"c", "b"},
+			[]int{443},
+			[]interface{}{
+				map[string]interface{}{"name": "web", "replicas": 3},
+				map[string]interface{}{"name": "db", "replicas": 1},
+			},
+		},
+		{
+			MergeByKey("name"),
+			[]interface{}{"a", "b", "c"},
+			[]int{80, 443},
+			[]interface{}{
+				map[string]interface{}{"name": "api", "replicas": 1},
+				map[string]interface{}{"name": "web", "replicas": 3},
+				map[string]interface{}{"name": "db"},
+			},
+		},
+	}
+	for i, expect := range expects {
+		data := _testMergeDefaults()
+		gp := New(data)
+		assert.Equal(t, 8080, gp.Get("server.port"), "value is cached")
+		assert.Nil(t, gp.Merge(override, expect.strategy), "Merge with strategy %d", i)
+		assert.Equal(t, map[string]interface{}{
+			"host":  "localhost",
+			"port":  9090,
+			"debug": true,
+			"tls":   map[string]interface{}{"cert": "a.pem"},
+		}, data["server"], "maps are merged, scalars casted into existing kind with strategy %d", i)
+		assert.Equal(t, 9090, gp.Get("server.port"), "cache is cleared")
+		assert.Equal(t, "value", data["new"])
+		assert.NotContains(t, data, "ignored", "nil values are ignored")
+		assert.Equal(t, expect.tags, data["tags"], "tags with strategy %d", i)
+		assert.Equal(t, expect.ports, data["ports"], "ports with strategy %d", i)
+		assert.Equal(t, expect.services, data["services"], "services with strategy %d", i)
+	}
+}
+
+func TestGPath_MergeErrors(t *testing.T) {
+	expects := []struct {
+		other  interface{}
+		expect string
+	}{
+		{map[string]interface{}{"server": "localhost"}, "cannot merge server: cannot merge string into map[string]interface {}"},
+		{map[string]interface{}{"tags": map[string]interface{}{}}, "cannot merge tags: cannot merge map[string]interface {} into []interface {}"},
+		{map[string]interface{}{"server": map[string]interface{}{"port": "high"}}, "cannot merge server.port: cannot cast string into int"},
+		{map[string]interface{}{"ports": []interface{}{"x"}}, "cannot merge ports.1: provided value is of string kind and cannot be cast into int kind"},
+	}
+	for _, expect := range expects {
+		data := _testMergeDefaults()
+		other := map[string]interface{}{"new": 1, "a": map[string]interface{}{"b": 2}}
+		for key, value := range expect.other.(map[string]interface{}) {
+			other[key] = value
+		}
+		err := New(data).Merge(other, MergeAppend)
+		assert.EqualError(t, err, expect.expect)
+		assert.Equal(t, _testMergeDefaults(), data, "nothing is changed on error")
+	}
+
+	err := New(map[string]interface{}{}).Merge([]interface{}{1}, MergeAppend)
+	assert.EqualError(t, err, "cannot merge root: cannot merge []interface {} into map[string]interface {}")
+	assert.NotNil(t, New([]interface{}{}).Merge([]interface{}{1}, MergeAppend), "root slice must be given as pointer")
+	assert.NotNil(t, New("string").Merge(map[string]interface{}{}, MergeAppend))
+	assert.Nil(t, New(map[string]interface{}{}).Merge(nil, MergeAppend))
+}
+
+func TestGPath_MergeTyped(t *testing.T) {
+	type service struct {
+		Name     string `json:"name"`
+		Replicas int    `json:"replicas"`
+	}
+	limits := map[string]int{"cpu": 1}
+	list := []string{"a"}
+	data := map[string]interface{}{
+		"limits":   limits,
+		"list":     &list,
+		"services": []service{{"api", 1}, {"web", 1}},
+	}
+	gp := New(data, WithSeparator("/"))
+	assert.Nil(t, gp.Merge(map[string]interface{}{
+		"limits":   map[string]interface{}{"cpu": "2", "memory": 512.0},
+		"list":     []interface{}{"b", 3},
+		"services": []interface{}{service{"web", 3}},
+	}, MergeByKey("name")))
+	assert.Equal(t, map[string]int{"cpu": 2, "memory": 512}, limits, "typed map is kept")
+	assert.Equal(t, []string{"a", "b", "3"}, list, "pointer to slice is modified in place")
+	assert.Equal(t, []service{{"api", 1}, {"web", 3}}, data["services"], "structs are merged by key")
+
+	root := []interface{}{1}
+	assert.Nil(t, New(&root).Merge([]interface{}{2}, MergeAppend))
+	assert.Equal(t, []interface{}{1, 2}, root)
+
+	err := gp.Merge(map[string]interface{}{"limits": map[string]interface{}{"cpu": "x"}}, MergeAppend)
+	assert.EqualError(t, err, "cannot merge limits/cpu: cannot cast string into int", "paths in notation of GPath")
+}
+
+func TestGPath_MergeStructs(t *testing.T) {
+	other := map[string]interface{}{"s": testStructPipeline{Steps: []string{"build"}}}
+	data := map[string]interface{}{"s": testStructPipeline{Steps: []string{"lint"}}}
+	assert.Nil(t, New(data).Merge(other, MergeAppend))
+	other["s"].(testStructPipeline).Steps[0] = "changed"
+	assert.Equal(t, testStructPipeline{Steps: []string{"build"}}, data["s"], "merged struct does not share slices")
+
+	ptr := map[string]interface{}{"p": &testStructPipeline{Steps: []string{"lint"}}}
+	assert.Nil(t, New(ptr).Merge(map[string]interface{}{"p": testStructPipeline{Steps: []string{"build"}}}, MergeAppend))
+	assert.Equal(t, &testStructPipeline{Steps: []string{"build"}}, ptr["p"], "pointer type is kept")
+
+	typed := map[string]*testStructPipeline{"a": {Steps: []string{"lint"}}}
+	src := &testStructPipeline{Steps: []string{"test"}}
+	assert.Nil(t, New(typed).Merge(map[string]interface{}{"a": testStructPipeline{Steps: []string{"build"}}, "b": src}, MergeAppend))
+	assert.Equal(t, map[string]*testStructPipeline{
+		"a": {Steps: []string{"build"}},
+		"b": {Steps: []string{"test"}},
+	}, typed)
+	assert.False(t, typed["b"] == src, "pointers are copied")
+}
+
+func TestGPath_MergeLayered(t *testing.T) {
+	defaults := map[string]interface{}{
+		"server": map[string]interface{}{"host": "localhost", "port": 80},
+		"tags":   []interface{}{map[string]interface{}{"name": "default"}},
+	}
+	env := map[string]interface{}{
+		"server": map[string]interface{}{"port": 8080},
+		"tags":   []interface{}{map[string]interface{}{"name": "env"}},
+	}
+	for _, strategy := range []MergeStrategy{MergeReplace, MergeAppend, MergeByIndex, MergeByKey("name")} {
+		gp := New(map[string]interface{}{})
+		assert.Nil(t, gp.Merge(defaults, strategy))
+		assert.Nil(t, gp.Merge(env, strategy))
+		assert.Equal(t, 8080, gp.Get("server.port"))
+		assert.Nil(t, gp.Set("server.host", "example.com"))
+		assert.Nil(t, gp.Set("tags.0.name", "changed"))
+		assert.Equal(t, map[string]interface{}{
+			"server": map[string]interface{}{"host": "localhost", "port": 80},
+			"tags":   []interface{}{map[string]interface{}{"name": "default"}},
+		}, defaults, "merged documents are not modified")
+		assert.Equal(t, map[string]interface{}{
+			"server": map[string]interface{}{"port": 8080},
+			"tags":   []interface{}{map[string]interface{}{"name": "env"}},
+		}, env, "merged documents are not modified")
+	}
+}
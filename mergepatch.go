@@ -0,0 +1,105 @@
+package gpath
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// MergePatch applies a JSON Merge Patch (RFC 7386): objects (maps or structs) of the patch are merged
+// recursively into the maps of the data structure, null (nil) values delete keys and all other values
+// replace the existing ones. Missing maps, or values which are no maps, are replaced by new maps (see
+// WithContainers). Values are casted into the types of typed maps (eg map[string]int). The patch is
+// atomic: if any value cannot be written, the data structure is left unchanged.
+func (gp *GPath) MergePatch(patch interface{}) error {
+	if jpKindOf(patch) != jpObject {
+		return errors.New("cannot replace the whole data structure, merge patch must be an object")
+	} else if ref := indirect(vof(gp.source)); !ref.IsValid() || ref.Kind() != reflect.Map || ref.IsNil() {
+		return errors.New("can only merge patch into map")
+	}
+	patch = deepCopy(patch)
+	dry := gp.child(deepCopy(gp.source))
+	if _, err := dry.mergePatch(dry.source, patch, nil); err != nil {
+		return err
+	}
+	defer gp.clearBelow(nil)
+	_, err := gp.mergePatch(gp.source, patch, nil)
+	return err
+}
+
+// mergePatch returns the result of merging patch into target. Existing maps are modified in place.
+func (gp *GPath) mergePatch(target, patch interface{}, segments []segment) (interface{}, error) {
+	if jpKindOf(patch) != jpObject {
+		return patch, nil
+	}
+	tref := indirect(vof(target))
+	if !tref.IsValid() || tref.Kind() != reflect.Map || tref.IsNil() {
+		if tref.IsValid() && tref.Kind() == reflect.Struct {
+			return nil, gp.mergePatchError(segments, "cannot patch struct %s", tref.Type())
+		}
+		target = gp.containers.newMap()
+		tref = vof(target)
+	}
+
+	for _, c := range children(patch) {
+		path := appendSegment(segments, c.segment)
+		key, err := fitMapKey(tref, vof(c.segment.key), true)
+		if err != nil {
+			return nil, gp.mergePatchError(path, "%s", err)
+		} else if jpKindOf(c.value) == jpNull {
+			tref.SetMapIndex(key, reflect.Value{})
+			continue
+		}
+		var existing interface{}
+		if v := tref.MapIndex(key); v.IsValid() {
+			existing = v.Interface()
+		}
+		merged, err := gp.mergePatch(existing, c.value, path)
+		if err != nil {
+			return nil, err
+		}
+		value, err := fitMapValue(tref, vof(merged), true)
+		if err != nil {
+			return nil, gp.mergePatchError(path, "%s", err)
+		}
+		tref.SetMapIndex(key, value)
+	}
+	return target, nil
+}
+
+func (gp *GPath) mergePatchError(segments []segment, format string, args ...interface{}) error {
+	return fmt.Errorf("cannot merge patch into %s: %s", gp.format(segments), fmt.Sprintf(format, args...))
+}
+
+// CreateMergePatch returns the JSON Merge Patch (RFC 7386), which turns document a into document b.
+// Objects (maps or structs) are compared recursively and the patch contains only what differs: keys
+// missing in b are nil, changed values are those of b. Slices are replaced as a whole. If b is no
+// object, the patch is b itself. Values are compared in the JSON data model (eg int(1) equals
+// float64(1)). Note that nil values in b cannot be expressed, as they delete keys.
+func CreateMergePatch(a, b interface{}) interface{} {
+	if jpKindOf(a) != jpObject || jpKindOf(b) != jpObject {
+		return b
+	}
+	patch := map[string]interface{}{}
+	existing := map[string]interface{}{}
+	for _, c := range children(a) {
+		existing[c.segment.key] = c.value
+	}
+	for _, c := range children(b) {
+		av, has := existing[c.segment.key]
+		delete(existing, c.segment.key)
+		if !has {
+			patch[c.segment.key] = c.value
+		} else if jpKindOf(av) == jpObject && jpKindOf(c.value) == jpObject {
+			if sub := CreateMergePatch(av, c.value).(map[string]interface{}); len(sub) > 0 {
+				patch[c.segment.key] = sub
+			}
+		} else if !jpEqual(av, c.value) {
+			patch[c.segment.key] = c.value
+		}
+	}
+	for key := range existing {
+		patch[key] = nil
+	}
+	return patch
+}
@@ -0,0 +1,99 @@
+package gpath
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestGPath_MergePatch(t *testing.T) {
+	// examples of RFC 7386, appendix A
+	expects := []struct {
+		target string
+		patch  string
+		expect string
+	}{
+		{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{`{"a":"b"}`, `{"a":null}`, `{}`},
+		{`{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{`{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`},
+		{`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{`{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{`{"e":null}`, `{"a":1}`, `{"e":null,"a":1}`},
+		{`{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+	}
+	for _, expect := range expects {
+		var target, patch, result map[string]interface{}
+		assert.Nil(t, json.Unmarshal([]byte(expect.target), &target))
+		assert.Nil(t, json.Unmarshal([]byte(expect.patch), &patch))
+		assert.Nil(t, json.Unmarshal([]byte(expect.expect), &result))
+		assert.Nil(t, New(target).MergePatch(patch), "Merge patch %s into %s", expect.patch, expect.target)
+		assert.Equal(t, result, target, "Merge patch %s into %s", expect.patch, expect.target)
+	}
+
+	data := map[string]interface{}{
+		"server": map[string]interface{}{"host": "localhost", "port": 8080},
+		"limits": map[string]int{"cpu": 1},
+	}
+	gp := New(data)
+	assert.Equal(t, "localhost", gp.Get("server.host"), "value is cached")
+	assert.Nil(t, gp.MergePatch(map[string]interface{}{
+		"server": map[string]interface{}{"host": nil, "tls": true},
+		"limits": map[string]interface{}{"cpu": "2"},
+	}))
+	assert.Nil(t, gp.Get("server.host"), "cache is cleared")
+	assert.Equal(t, map[string]interface{}{"port": 8080, "tls": true}, data["server"])
+	assert.Equal(t, map[string]int{"cpu": 2}, data["limits"], "typed map is kept")
+
+	err := gp.MergePatch(map[string]interface{}{"new": 1, "limits": map[string]interface{}{"cpu": "x"}})
+	assert.EqualError(t, err, "cannot merge patch into limits.cpu: provided value is of string kind and cannot be cast into int kind")
+	assert.NotContains(t, data, "new", "nothing is changed on error")
+	assert.NotNil(t, gp.MergePatch("string"), "patch must be an object")
+	assert.NotNil(t, New([]interface{}{}).MergePatch(map[string]interface{}{}), "target must be a map")
+
+	cgp := New(map[interface{}]interface{}{}, WithContainers(map[interface{}]interface{}{}, nil))
+	assert.Nil(t, cgp.MergePatch(map[string]interface{}{"a": map[string]interface{}{"b": 1}}))
+	assert.Equal(t, map[interface{}]interface{}{"b": 1}, cgp.Get("a"), "new maps are of configured type")
+}
+
+func TestCreateMergePatch(t *testing.T) {
+	type server struct {
+		Host string `json:"host"`
+		Port int    `json:"port"`
+	}
+	expects := []struct {
+		a      interface{}
+		b      interface{}
+		expect interface{}
+	}{
+		{
+			map[string]interface{}{"a": "b", "c": map[string]interface{}{"d": "e", "f": "g"}, "x": []interface{}{1}},
+			map[string]interface{}{"a": "z", "c": map[string]interface{}{"d": "e"}, "x": []interface{}{1}, "n": 1},
+			map[string]interface{}{"a": "z", "c": map[string]interface{}{"f": nil}, "n": 1},
+		},
+		{
+			map[string]interface{}{"list": []interface{}{1, 2}, "same": map[string]interface{}{"a": 1}},
+			map[string]interface{}{"list": []interface{}{1}, "same": map[string]interface{}{"a": 1.0}},
+			map[string]interface{}{"list": []interface{}{1}},
+		},
+		{
+			map[string]interface{}{"server": server{"localhost", 80}},
+			map[string]interface{}{"server": map[string]interface{}{"host": "localhost", "port": 443}},
+			map[string]interface{}{"server": map[string]interface{}{"port": 443}},
+		},
+		{map[string]interface{}{"a": 1}, map[string]interface{}{"a": 1}, map[string]interface{}{}},
+		{map[string]interface{}{"a": 1}, []interface{}{1}, []interface{}{1}},
+		{"a", map[string]interface{}{"a": 1}, map[string]interface{}{"a": 1}},
+	}
+	for _, expect := range expects {
+		patch := CreateMergePatch(expect.a, expect.b)
+		assert.Equal(t, expect.expect, patch, "Merge patch of %v to %v", expect.a, expect.b)
+	}
+
+	a := map[string]interface{}{"a": map[string]interface{}{"b": 1, "c": 2}, "d": "e"}
+	b := map[string]interface{}{"a": map[string]interface{}{"b": 3}, "f": []interface{}{"g"}}
+	assert.Nil(t, New(a).MergePatch(CreateMergePatch(a, b)))
+	assert.Equal(t, b, a, "created patch turns a into b")
+}
@@ -0,0 +1,202 @@
+package gpath
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// PatchOperation is an operation of a JSON Patch (RFC 6902), see GPath.ApplyPatch
+type PatchOperation struct {
+	// Op is one of "add", "remove", "replace", "move", "copy" or "test"
+	Op string `json:"op"`
+
+	// Path is the JSON Pointer of the target of the operation
+	Path string `json:"path"`
+
+	// From is the JSON Pointer of the source of "move" and "copy"
+	From string `json:"from,omitempty"`
+
+	// Value is the value of "add", "replace" and "test"
+	Value interface{} `json:"value"`
+}
+
+// DecodePatch decodes a JSON Patch document, which is an array of operations
+func DecodePatch(data []byte) ([]PatchOperation, error) {
+	ops := []PatchOperation{}
+	if err := json.Unmarshal(data, &ops); err != nil {
+		return nil, err
+	}
+	return ops, nil
+}
+
+// ApplyPatch applies the operations of a JSON Patch (RFC 6902) in order. Paths are JSON Pointers,
+// independent of the notation of the GPath. "add" inserts into slices (the token "-" appends) and
+// adds or replaces keys of maps, "test" compares in the JSON data model (eg int(1) equals float64(1)).
+// The patch is atomic: all operations are applied to a copy first, so the data structure is left
+// unchanged if any operation fails. Replacing the whole data structure (path "") is not supported.
+func (gp *GPath) ApplyPatch(ops []PatchOperation) error {
+	if err := gp.child(deepCopy(gp.source)).applyPatch(ops); err != nil {
+		return err
+	}
+	defer gp.clearBelow(nil)
+	return gp.applyPatch(ops)
+}
+
+func (gp *GPath) applyPatch(ops []PatchOperation) error {
+	for i, op := range ops {
+		var err error
+		switch op.Op {
+		case "add":
+			err = gp.patchAdd(op.Path, op.Value)
+		case "remove":
+			_, err = gp.patchRemove(op.Path)
+		case "replace":
+			if _, err = gp.patchRemove(op.Path); err == nil {
+				err = gp.patchAdd(op.Path, op.Value)
+			}
+		case "move":
+			err = gp.patchMove(op.From, op.Path)
+		case "copy":
+			var val interface{}
+			if val, err = gp.patchGet(op.From); err == nil {
+				err = gp.patchAdd(op.Path, deepCopy(val))
+			}
+		case "test":
+			var val interface{}
+			if val, err = gp.patchGet(op.Path); err == nil && !jpEqual(val, op.Value) {
+				err = errors.New("test failed")
+			}
+		default:
+			err = fmt.Errorf("unsupported operation \"%s\"", op.Op)
+		}
+		if err != nil {
+			return fmt.Errorf("patch operation %d (%s %s): %s", i, op.Op, op.Path, err)
+		}
+	}
+	return nil
+}
+
+// patchGet returns the value of an existing pointer
+func (gp *GPath) patchGet(pointer string) (interface{}, error) {
+	segments, err := gp.pointerSegments(pointer, false, false)
+	if err != nil {
+		return nil, err
+	} else if val, has := gp.getSegments(segments); has {
+		return val, nil
+	}
+	return nil, fmt.Errorf("path %s does not exist", pointer)
+}
+
+// patchAdd inserts the value into a slice or sets the key of a map
+func (gp *GPath) patchAdd(pointer string, value interface{}) error {
+	segments, err := gp.pointerSegments(pointer, true, false)
+	if err != nil {
+		return err
+	} else if len(segments) == 0 {
+		return errors.New("cannot replace the whole data structure")
+	}
+	last := segments[len(segments)-1]
+	if last.kind != segmentIndex {
+		return gp.set(segments, value)
+	}
+	return gp.modifySliceSegments(segments[0:len(segments)-1], func(ref reflect.Value) error {
+		idx := last.index
+		if idx == -1 {
+			idx = ref.Elem().Len()
+		}
+		return SliceIndexValueInsert(ref, idx, vof(value), true)
+	})
+}
+
+// patchRemove removes the value of an existing pointer and returns it
+func (gp *GPath) patchRemove(pointer string) (interface{}, error) {
+	val, err := gp.patchGet(pointer)
+	if err != nil {
+		return nil, err
+	}
+	segments, _ := gp.pointerSegments(pointer, false, false)
+	if len(segments) == 0 {
+		return nil, errors.New("cannot remove the whole data structure")
+	}
+	return val, gp.delete(segments)
+}
+
+// patchMove removes the value of from and adds it at to, which must not be a child of from
+func (gp *GPath) patchMove(from, to string) error {
+	if to == from {
+		_, err := gp.patchGet(from)
+		return err
+	} else if strings.HasPrefix(to, from+"/") {
+		return fmt.Errorf("cannot move %s into its own child", from)
+	}
+	val, err := gp.patchRemove(from)
+	if err != nil {
+		return err
+	}
+	return gp.patchAdd(to, val)
+}
+
+// deepCopy returns a copy of the value, in which all maps, slices, arrays, pointers and exported
+// struct fields are copied recursively, keeping their types
+func deepCopy(val interface{}) interface{} {
+	if val == nil {
+		return nil
+	}
+	return deepCopyValue(vof(val)).Interface()
+}
+
+func deepCopyValue(ref reflect.Value) reflect.Value {
+	switch ref.Kind() {
+	case reflect.Map:
+		if ref.IsNil() {
+			return ref
+		}
+		res := reflect.MakeMap(ref.Type())
+		for _, key := range ref.MapKeys() {
+			res.SetMapIndex(key, deepCopyValue(ref.MapIndex(key)))
+		}
+		return res
+	case reflect.Slice:
+		if ref.IsNil() {
+			return ref
+		}
+		res := reflect.MakeSlice(ref.Type(), ref.Len(), ref.Len())
+		for i := 0; i < ref.Len(); i++ {
+			res.Index(i).Set(deepCopyValue(ref.Index(i)))
+		}
+		return res
+	case reflect.Array:
+		res := reflect.New(ref.Type()).Elem()
+		for i := 0; i < ref.Len(); i++ {
+			res.Index(i).Set(deepCopyValue(ref.Index(i)))
+		}
+		return res
+	case reflect.Ptr:
+		if ref.IsNil() {
+			return ref
+		}
+		res := reflect.New(ref.Type().Elem())
+		res.Elem().Set(deepCopyValue(ref.Elem()))
+		return res
+	case reflect.Interface:
+		if ref.IsNil() {
+			return ref
+		}
+		res := reflect.New(ref.Type()).Elem()
+		res.Set(deepCopyValue(ref.Elem()))
+		return res
+	case reflect.Struct:
+		res := reflect.New(ref.Type()).Elem()
+		res.Set(ref)
+		for i := 0; i < ref.NumField(); i++ {
+			if res.Field(i).CanSet() {
+				res.Field(i).Set(deepCopyValue(ref.Field(i)))
+			}
+		}
+		return res
+	}
+	return ref
+}
@@ -0,0 +1,142 @@
+package gpath
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func _testPatchData() map[string]interface{} {
+	return map[string]interface{}{
+		"name":  "config",
+		"tags":  []interface{}{"a", "b"},
+		"ports": []int{80, 443},
+		"db":    map[string]interface{}{"host": "localhost", "port": 5432},
+		"a/b":   map[string]interface{}{"~": 1},
+	}
+}
+
+func TestGPath_ApplyPatch(t *testing.T) {
+	expects := []struct {
+		op     PatchOperation
+		path   string
+		expect interface{}
+	}{
+		{PatchOperation{Op: "add", Path: "/tags/1", Value: "x"}, "tags", []interface{}{"a", "x", "b"}},
+		{PatchOperation{Op: "add", Path: "/tags/-", Value: "x"}, "tags", []interface{}{"a", "b", "x"}},
+		{PatchOperation{Op: "add", Path: "/tags/2", Value: "x"}, "tags", []interface{}{"a", "b", "x"}},
+		{PatchOperation{Op: "add", Path: "/ports/0", Value: 8080.0}, "ports", []int{8080, 80, 443}},
+		{PatchOperation{Op: "add", Path: "/db/user", Value: "admin"}, "db.user", "admin"},
+		{PatchOperation{Op: "add", Path: "/db/host", Value: "example.com"}, "db.host", "example.com"},
+		{PatchOperation{Op: "add", Path: "/db/pass", Value: nil}, "db", map[string]interface{}{"host": "localhost", "port": 5432, "pass": nil}},
+		{PatchOperation{Op: "add", Path: "/a~1b/~0", Value: 2}, `["a/b"]["~"]`, 2},
+		{PatchOperation{Op: "remove", Path: "/tags/0"}, "tags", []interface{}{"b"}},
+		{PatchOperation{Op: "remove", Path: "/db/port"}, "db", map[string]interface{}{"host": "localhost"}},
+		{PatchOperation{Op: "replace", Path: "/tags/0", Value: "z"}, "tags", []interface{}{"z", "b"}},
+		{PatchOperation{Op: "replace", Path: "/name", Value: map[string]interface{}{"x": 1}}, "name.x", 1},
+		{PatchOperation{Op: "move", From: "/db/host", Path: "/host"}, "", map[string]interface{}{"port": 5432}},
+		{PatchOperation{Op: "move", From: "/tags/0", Path: "/tags/-"}, "tags", []interface{}{"b", "a"}},
+		{PatchOperation{Op: "move", From: "/name", Path: "/name"}, "name", "config"},
+		{PatchOperation{Op: "copy", From: "/db", Path: "/db2"}, "db2", map[string]interface{}{"host": "localhost", "port": 5432}},
+		{PatchOperation{Op: "copy", From: "/tags/1", Path: "/tags/0"}, "tags", []interface{}{"b", "a", "b"}},
+		{PatchOperation{Op: "test", Path: "/db/port", Value: 5432.0}, "db.port", 5432},
+		{PatchOperation{Op: "test", Path: "/tags", Value: []interface{}{"a", "b"}}, "tags", []interface{}{"a", "b"}},
+	}
+	for _, expect := range expects {
+		gp := New(_testPatchData())
+		assert.Equal(t, "localhost", gp.Get("db.host"), "value is cached")
+		assert.Nil(t, gp.ApplyPatch([]PatchOperation{expect.op}), "Apply %s %s", expect.op.Op, expect.op.Path)
+		if expect.path == "" {
+			assert.Equal(t, "localhost", gp.Get("host"), "Apply %s %s", expect.op.Op, expect.op.Path)
+			assert.Equal(t, expect.expect, gp.Get("db"), "Apply %s %s", expect.op.Op, expect.op.Path)
+		} else {
+			assert.Equal(t, expect.expect, gp.Get(expect.path), "Apply %s %s", expect.op.Op, expect.op.Path)
+		}
+	}
+
+	data := _testPatchData()
+	gp := New(data)
+	assert.Nil(t, gp.ApplyPatch([]PatchOperation{{Op: "copy", From: "/db", Path: "/db2"}}))
+	assert.Nil(t, gp.Set("db2.host", "other"))
+	assert.Equal(t, "localhost", gp.Get("db.host"), "copy is deep")
+}
+
+func TestGPath_ApplyPatchErrors(t *testing.T) {
+	expects := []struct {
+		op     PatchOperation
+		expect string
+	}{
+		{PatchOperation{Op: "add", Path: "/tags/4", Value: "x"}, "patch operation 1 (add /tags/4): could not modify tags (provided index 4 is out of bounds for slice of len 3)"},
+		{PatchOperation{Op: "add", Path: "/missing/key", Value: "x"}, "patch operation 1 (add /missing/key): parent element /missing of pointer /missing/key does not exist"},
+		{PatchOperation{Op: "add", Path: "/ports/-", Value: "x"}, "patch operation 1 (add /ports/-): could not modify ports (provided value is of string kind and cannot be cast into int kind)"},
+		{PatchOperation{Op: "add", Path: "", Value: "x"}, "patch operation 1 (add ): cannot replace the whole data structure"},
+		{PatchOperation{Op: "remove", Path: "/db/user"}, "patch operation 1 (remove /db/user): path /db/user does not exist"},
+		{PatchOperation{Op: "remove", Path: "/tags/-"}, `patch operation 1 (remove /tags/-): invalid array index "-" in pointer /tags/-`},
+		{PatchOperation{Op: "replace", Path: "/db/user", Value: 1}, "patch operation 1 (replace /db/user): path /db/user does not exist"},
+		{PatchOperation{Op: "move", From: "/db", Path: "/db/inner"}, "patch operation 1 (move /db/inner): cannot move /db into its own child"},
+		{PatchOperation{Op: "copy", From: "/other", Path: "/x"}, "patch operation 1 (copy /x): path /other does not exist"},
+		{PatchOperation{Op: "test", Path: "/db/port", Value: "5432"}, "patch operation 1 (test /db/port): test failed"},
+		{PatchOperation{Op: "invalid", Path: "/db"}, `patch operation 1 (invalid /db): unsupported operation "invalid"`},
+	}
+	for _, expect := range expects {
+		data := _testPatchData()
+		gp := New(data)
+		err := gp.ApplyPatch([]PatchOperation{{Op: "add", Path: "/tags/0", Value: "first"}, expect.op})
+		assert.EqualError(t, err, expect.expect)
+		assert.Equal(t, _testPatchData(), data, "nothing is changed, if any operation fails")
+	}
+}
+
+func TestDecodePatch(t *testing.T) {
+	ops, err := DecodePatch([]byte(`[
+		{"op": "test", "path": "/name", "value": "config"},
+		{"op": "replace", "path": "/db/port", "value": 5433},
+		{"op": "add", "path": "/tags/-", "value": {"c": null}},
+		{"op": "move", "from": "/db/host", "path": "/host"}
+	]`))
+	assert.Nil(t, err)
+	assert.Equal(t, []PatchOperation{
+		{Op: "test", Path: "/name", Value: "config"},
+		{Op: "replace", Path: "/db/port", Value: 5433.0},
+		{Op: "add", Path: "/tags/-", Value: map[string]interface{}{"c": nil}},
+		{Op: "move", From: "/db/host", Path: "/host"},
+	}, ops)
+
+	data := _testPatchData()
+	assert.Nil(t, New(data).ApplyPatch(ops))
+	assert.Equal(t, map[string]interface{}{
+		"name":  "config",
+		"host":  "localhost",
+		"tags":  []interface{}{"a", "b", map[string]interface{}{"c": nil}},
+		"ports": []int{80, 443},
+		"db":    map[string]interface{}{"port": 5433.0},
+		"a/b":   map[string]interface{}{"~": 1},
+	}, data)
+
+	_, err = DecodePatch([]byte(`{"op": "add"}`))
+	assert.NotNil(t, err, "patch must be array")
+}
+
+func Test_deepCopy(t *testing.T) {
+	type inner struct {
+		Tags  []string
+		names map[string]int
+	}
+	list := []interface{}{1, map[string]interface{}{"a": []int{1}}}
+	src := map[string]interface{}{
+		"list":   list,
+		"ptr":    &list,
+		"struct": inner{Tags: []string{"a"}, names: map[string]int{"a": 1}},
+		"array":  [2]interface{}{[]int{1}, nil},
+		"nil":    nil,
+	}
+	res := deepCopy(src).(map[string]interface{})
+	assert.Equal(t, src, res)
+
+	res["list"].([]interface{})[1].(map[string]interface{})["a"].([]int)[0] = 2
+	(*res["ptr"].(*[]interface{}))[0] = 3
+	res["struct"].(inner).Tags[0] = "b"
+	assert.Equal(t, []int{1}, list[1].(map[string]interface{})["a"], "nested values are copied")
+	assert.Equal(t, 1, list[0], "pointees are copied")
+	assert.Equal(t, []string{"a"}, src["struct"].(inner).Tags, "exported struct fields are copied")
+	assert.Nil(t, deepCopy(nil))
+}
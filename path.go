@@ -0,0 +1,424 @@
+package gpath
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// segmentKind describes what a segment addresses
+type segmentKind int
+
+const (
+	// segmentKey addresses a key of a map or a field of a struct
+	segmentKey segmentKind = iota
+
+	// segmentIndex addresses an element of a slice
+	segmentIndex
+
+	// segmentWildcard addresses all elements of a map, slice or struct
+	segmentWildcard
+
+	// segmentRecursive addresses the current value and all its descendants at any depth
+	segmentRecursive
+
+	// segmentFilter addresses all elements of a map, slice or struct matching a predicate
+	segmentFilter
+
+	// segmentRange addresses a sub-slice of a slice
+	segmentRange
+)
+
+// segment is a single step within a path. For ranges, index is the (inclusive) start and end the
+// (exclusive) end, unless the range is open ended
+type segment struct {
+	kind   segmentKind
+	key    string
+	index  int
+	end    int
+	open   bool
+	filter Predicate
+}
+
+func keySegment(key string) segment {
+	return segment{kind: segmentKey, key: key}
+}
+
+func indexSegment(index int) segment {
+	return segment{kind: segmentIndex, index: index}
+}
+
+func wildcardSegment() segment {
+	return segment{kind: segmentWildcard}
+}
+
+func recursiveSegment() segment {
+	return segment{kind: segmentRecursive}
+}
+
+func rangeSegment(start, end int, open bool) segment {
+	return segment{kind: segmentRange, index: start, end: end, open: open}
+}
+
+// bounds returns the actual start and end of a range segment within a slice of given length. Like
+// in Python, negative values count from the end and values out of bounds are clipped.
+func (s segment) bounds(length int) (int, int) {
+	clip := func(idx int) int {
+		if idx < 0 {
+			idx += length
+		}
+		if idx < 0 {
+			return 0
+		} else if idx > length {
+			return length
+		}
+		return idx
+	}
+	start, end := clip(s.index), length
+	if !s.open {
+		end = clip(s.end)
+	}
+	if end < start {
+		end = start
+	}
+	return start, end
+}
+
+// filterSegment returns a segment for given predicate. The expression is used for the notation of the
+// segment and is empty for programmatically build predicates
+func filterSegment(predicate Predicate, expr string) segment {
+	return segment{kind: segmentFilter, filter: predicate, key: expr}
+}
+
+// String returns the segment in path notation, escaped as required
+func (s segment) String() string {
+	return s.format(".")
+}
+
+// format returns the segment in path notation with given separator, escaped as required
+func (s segment) format(separator string) string {
+	switch s.kind {
+	case segmentIndex:
+		if str := strconv.Itoa(s.index); strings.Index(str+separator, separator) == len(str) {
+			return str
+		}
+		return "[" + strconv.Itoa(s.index) + "]"
+	case segmentWildcard:
+		return "*"
+	case segmentRecursive:
+		return "**"
+	case segmentFilter:
+		return "[?" + s.key + "]"
+	case segmentRange:
+		str := "["
+		if s.index != 0 {
+			str += strconv.Itoa(s.index)
+		}
+		str += ":"
+		if !s.open {
+			str += strconv.Itoa(s.end)
+		}
+		return str + "]"
+	}
+	if isBareKey(s.key, separator) {
+		return s.key
+	}
+	return quoteKey(s.key)
+}
+
+// parsePath splits a path in its segments. Segments are separated by ".". Within a segment, any
+// character can be escaped with a backslash (`a\.b`). Alternatively keys can be quoted in brackets
+// (`hosts["example.com"].port` or `hosts['example.com'].port`) and indices can be given in brackets
+// (`users[0].roles[2]`). Unquoted segments consisting of digits only are slice indices, so map keys
+// consisting of digits only must be quoted (`codes["404"]`). Negative indices count from the end of
+// the slice (`items.-1` or `items[-1]` is the last element). An unescaped "*" (or `[*]`) is a
+// wildcard, which matches all elements, and an unescaped "**" descends recursively, matching at any
+// depth (see GPath.Query). Filters in brackets (`users[?@.age > 30]`) match all elements for which
+// the predicate expression is true (see ParsePredicate). Ranges in brackets (`items[1:3]`, `items[:3]`,
+// `items[-2:]`) address a sub-slice from start (inclusive) to end (exclusive).
+func parsePath(path string) ([]segment, error) {
+	return parsePathSeparator(path, ".")
+}
+
+// parsePathSeparator works as parsePath, but segments are separated by given separator instead of "."
+func parsePathSeparator(path, separator string) ([]segment, error) {
+	return splitPath(path, separator, false)
+}
+
+// splitPath splits a path into segments separated by given separator. Strict mode rejects empty
+// segments (`a..b`, `a.[0]`) and trailing separators (`a.b.`), which are read as empty keys otherwise.
+func splitPath(path, separator string, strict bool) ([]segment, error) {
+	if strict && path == "" {
+		return nil, pathErrorf(path, 0, "empty path")
+	}
+	segments := []segment{}
+	for pos := 0; ; {
+		var seg segment
+		var err error
+		start := pos
+		if pos < len(path) && path[pos] == '[' {
+			seg, pos, err = parseBracket(path, pos)
+		} else if seg, pos, err = parseBare(path, pos, separator); err == nil && strict && pos == start {
+			err = pathErrorf(path, start, "empty segment")
+		}
+		if err != nil {
+			return nil, err
+		}
+		segments = append(segments, seg)
+
+		if pos >= len(path) {
+			return segments, nil
+		} else if strings.HasPrefix(path[pos:], separator) {
+			if pos += len(separator); strict && pos >= len(path) {
+				return nil, pathErrorf(path, pos-len(separator), "trailing separator")
+			} else if strict && path[pos] == '[' {
+				return nil, pathErrorf(path, pos, "empty segment")
+			}
+		} else if path[pos] != '[' {
+			return nil, pathErrorf(path, pos, "unexpected %q", path[pos])
+		}
+	}
+}
+
+// parseBare reads an unquoted segment starting at pos, until the next unescaped separator or "[". An
+// empty separator only ends the segment at "[".
+func parseBare(path string, pos int, separator string) (segment, int, error) {
+	start := pos
+	buf := new(bytes.Buffer)
+	escaped := false
+	for ; pos < len(path); pos++ {
+		c := path[pos]
+		if c == '\\' {
+			if pos+1 >= len(path) {
+				return segment{}, pos, pathErrorf(path, pos, "dangling escape")
+			}
+			pos++
+			buf.WriteByte(path[pos])
+			escaped = true
+			continue
+		} else if c == '[' || (separator != "" && strings.HasPrefix(path[pos:], separator)) {
+			break
+		} else if c == ']' {
+			return segment{}, pos, pathErrorf(path, pos, "unexpected %q", c)
+		}
+		buf.WriteByte(c)
+	}
+	word := buf.String()
+	if !escaped && word == "*" {
+		return wildcardSegment(), pos, nil
+	} else if !escaped && word == "**" {
+		return recursiveSegment(), pos, nil
+	} else if !escaped && isInt(word) {
+		idx, err := strconv.Atoi(word)
+		if err != nil {
+			return segment{}, pos, pathErrorf(path, start, "index %s out of range", word)
+		}
+		return indexSegment(idx), pos, nil
+	}
+	return keySegment(word), pos, nil
+}
+
+// parseBracket reads a quoted key (`["key"]`) or an index (`[0]`) in brackets starting at the opening
+// bracket at pos
+func parseBracket(path string, pos int) (segment, int, error) {
+	start := pos
+	pos++
+	if strings.HasPrefix(path[pos:], "*]") {
+		return wildcardSegment(), pos + 2, nil
+	} else if strings.HasPrefix(path[pos:], "?") {
+		return parseBracketFilter(path, start)
+	} else if pos < len(path) && (path[pos] == '-' || path[pos] == ':' || (path[pos] >= '0' && path[pos] <= '9')) {
+		return parseBracketIndex(path, start)
+	} else if pos >= len(path) || (path[pos] != '"' && path[pos] != '\'') {
+		return segment{}, pos, pathErrorf(path, pos, "expected quote or index after \"[\"")
+	}
+	quote := path[pos]
+	buf := new(bytes.Buffer)
+	for pos++; pos < len(path) && path[pos] != quote; pos++ {
+		if path[pos] == '\\' {
+			if pos+1 >= len(path) {
+				break
+			}
+			pos++
+		}
+		buf.WriteByte(path[pos])
+	}
+	if pos >= len(path) {
+		return segment{}, pos, pathErrorf(path, start, "unterminated quote of bracket")
+	}
+	pos++
+	if pos >= len(path) || path[pos] != ']' {
+		return segment{}, pos, pathErrorf(path, start, "unclosed bracket")
+	}
+	return keySegment(buf.String()), pos + 1, nil
+}
+
+// parseBracketFilter reads a filter expression in brackets (`[?@.age > 30]`) starting at the opening
+// bracket at pos
+func parseBracketFilter(path string, pos int) (segment, int, error) {
+	start := pos
+	depth := 0
+	for pos += 2; pos < len(path); pos++ {
+		switch c := path[pos]; c {
+		case '\\':
+			pos++
+		case '"', '\'':
+			for pos++; pos < len(path) && path[pos] != c; pos++ {
+				if path[pos] == '\\' {
+					pos++
+				}
+			}
+		case '[':
+			depth++
+		case ']':
+			if depth == 0 {
+				expr := path[start+2 : pos]
+				predicate, err := ParsePredicate(expr)
+				if err != nil {
+					return segment{}, start + 2, pathErrorf(path, start+2, "invalid filter: %s", err)
+				}
+				return filterSegment(predicate, expr), pos + 1, nil
+			}
+			depth--
+		}
+	}
+	return segment{}, pos, pathErrorf(path, start, "unclosed bracket")
+}
+
+// parseBracketIndex reads an index (`[0]`) or a range (`[1:3]`) in brackets starting at the opening
+// bracket at pos
+func parseBracketIndex(path string, pos int) (segment, int, error) {
+	start := pos
+	readInt := func(pos int) (int, int, bool, error) {
+		end := pos
+		if end < len(path) && path[end] == '-' {
+			end++
+		}
+		for end < len(path) && path[end] >= '0' && path[end] <= '9' {
+			end++
+		}
+		if end == pos {
+			return 0, end, false, nil
+		}
+		idx, err := strconv.Atoi(path[pos:end])
+		if err != nil {
+			return 0, end, false, pathErrorf(path, pos, "index %s out of range", path[pos:end])
+		}
+		return idx, end, true, nil
+	}
+
+	from, pos, hasFrom, err := readInt(pos + 1)
+	if err != nil {
+		return segment{}, pos, err
+	} else if pos < len(path) && path[pos] == ']' && hasFrom {
+		return indexSegment(from), pos + 1, nil
+	} else if pos >= len(path) || path[pos] != ':' {
+		return segment{}, pos, pathErrorf(path, start, "unclosed bracket")
+	}
+	to, pos, hasTo, err := readInt(pos + 1)
+	if err != nil {
+		return segment{}, pos, err
+	} else if pos >= len(path) || path[pos] != ']' {
+		return segment{}, pos, pathErrorf(path, start, "unclosed bracket")
+	}
+	return rangeSegment(from, to, !hasTo), pos + 1, nil
+}
+
+// formatPath returns the canonical notation of given segments, which parses back into the same
+// segments. It is used as cache key.
+func formatPath(segments []segment) string {
+	return formatPathSeparator(segments, ".")
+}
+
+// formatPathSeparator works as formatPath, but segments are separated by given separator
+func formatPathSeparator(segments []segment, separator string) string {
+	buf := new(bytes.Buffer)
+	for i, seg := range segments {
+		str := seg.format(separator)
+		if i > 0 && str[0] != '[' {
+			buf.WriteString(separator)
+		}
+		buf.WriteString(str)
+	}
+	return buf.String()
+}
+
+// isBareKey returns whether key can be written without quoting in a path with given separator. The
+// separator must not be found within the key, nor overlap with its end (eg key "a_" and separator "__").
+func isBareKey(key, separator string) bool {
+	if key == "" || key == "*" || key == "**" || isInt(key) || strings.Index(key+separator, separator) != len(key) {
+		return false
+	}
+	for i := 0; i < len(key); i++ {
+		switch key[i] {
+		case '[', ']', '\\':
+			return false
+		}
+	}
+	return true
+}
+
+// quoteKey returns key in bracket notation (`["key"]`)
+func quoteKey(key string) string {
+	buf := new(bytes.Buffer)
+	buf.WriteString(`["`)
+	for i := 0; i < len(key); i++ {
+		if c := key[i]; c == '"' || c == '\\' {
+			buf.WriteByte('\\')
+		}
+		buf.WriteByte(key[i])
+	}
+	buf.WriteString(`"]`)
+	return buf.String()
+}
+
+func isInt(word string) bool {
+	if len(word) > 1 && word[0] == '-' {
+		word = word[1:]
+	}
+	return isUInt(word)
+}
+
+func isUInt(word string) bool {
+	for _, c := range word {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return word != ""
+}
+
+// PathError describes why and where a path is malformed
+type PathError struct {
+	// Path is the malformed path
+	Path string
+
+	// Offset is the position (in bytes) within the path, at which the problem was found
+	Offset int
+
+	// Reason describes the problem, eg "empty segment"
+	Reason string
+}
+
+func pathErrorf(path string, offset int, format string, args ...interface{}) error {
+	return &PathError{Path: path, Offset: offset, Reason: fmt.Sprintf(format, args...)}
+}
+
+// Error returns the reason and the position, eg `empty segment at offset 6 in path users..name`
+func (e *PathError) Error() string {
+	return fmt.Sprintf("%s at offset %d in path %s", e.Reason, e.Offset, e.Path)
+}
+
+// Caret returns the path and, in the line below, a caret pointing at the problem:
+//
+//	users..name
+//	      ^
+func (e *PathError) Caret() string {
+	offset := e.Offset
+	if offset > len(e.Path) {
+		offset = len(e.Path)
+	}
+	return e.Path + "\n" + strings.Repeat(" ", utf8.RuneCountInString(e.Path[:offset])) + "^"
+}
@@ -0,0 +1,154 @@
+package gpath
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_parsePath(t *testing.T) {
+	expects := []struct {
+		path   string
+		expect []segment
+	}{
+		{"foo", []segment{keySegment("foo")}},
+		{"foo.bar", []segment{keySegment("foo"), keySegment("bar")}},
+		{"foo.0.bar", []segment{keySegment("foo"), indexSegment(0), keySegment("bar")}},
+		{"foo.-1", []segment{keySegment("foo"), indexSegment(-1)}},
+		{"foo[-2]", []segment{keySegment("foo"), indexSegment(-2)}},
+		{`foo.\-1`, []segment{keySegment("foo"), keySegment("-1")}},
+		{"foo.-", []segment{keySegment("foo"), keySegment("-")}},
+		{`foo\.bar`, []segment{keySegment("foo.bar")}},
+		{`foo\\.bar`, []segment{keySegment(`foo\`), keySegment("bar")}},
+		{`v1\.2.x`, []segment{keySegment("v1.2"), keySegment("x")}},
+		{`foo.\0`, []segment{keySegment("foo"), keySegment("0")}},
+		{`hosts["example.com"].port`, []segment{keySegment("hosts"), keySegment("example.com"), keySegment("port")}},
+		{`hosts['example.com'].port`, []segment{keySegment("hosts"), keySegment("example.com"), keySegment("port")}},
+		{`["a.b"]["c"]`, []segment{keySegment("a.b"), keySegment("c")}},
+		{`a["\"q\"\\"]`, []segment{keySegment("a"), keySegment(`"q"\`)}},
+		{`a["0"]`, []segment{keySegment("a"), keySegment("0")}},
+		{`a["it's"]`, []segment{keySegment("a"), keySegment("it's")}},
+		{"", []segment{keySegment("")}},
+		{"users[0].roles[2]", []segment{keySegment("users"), indexSegment(0), keySegment("roles"), indexSegment(2)}},
+		{"users[0][1]", []segment{keySegment("users"), indexSegment(0), indexSegment(1)}},
+		{"users.0[1].name", []segment{keySegment("users"), indexSegment(0), indexSegment(1), keySegment("name")}},
+		{"[3]", []segment{indexSegment(3)}},
+		{`codes["404"].text`, []segment{keySegment("codes"), keySegment("404"), keySegment("text")}},
+		{"items[1:4]", []segment{keySegment("items"), rangeSegment(1, 4, false)}},
+		{"items[:3]", []segment{keySegment("items"), rangeSegment(0, 3, false)}},
+		{"items[-2:]", []segment{keySegment("items"), rangeSegment(-2, 0, true)}},
+		{"items[:]", []segment{keySegment("items"), rangeSegment(0, 0, true)}},
+		{"items[1:-1].name", []segment{keySegment("items"), rangeSegment(1, -1, false), keySegment("name")}},
+	}
+	for _, expect := range expects {
+		segments, err := parsePath(expect.path)
+		assert.Nil(t, err, "Path %s should parse", expect.path)
+		assert.Equal(t, expect.expect, segments, "Path %s should be parsed", expect.path)
+	}
+
+	for _, path := range []string{`foo\`, `foo["bar`, `foo["bar"`, `foo[bar]`, `foo["bar"]x`, `foo]`, `foo.99999999999999999999`, `foo[1`, `foo[1a]`, `foo[]`, `foo[-]`, `foo[--1]`, `foo[1:2:3]`, `foo[1:a]`, `foo[1:`, `foo[99999999999999999999]`} {
+		_, err := parsePath(path)
+		assert.NotNil(t, err, "Path %s should not parse", path)
+	}
+}
+
+func Test_formatPath(t *testing.T) {
+	expects := []struct {
+		segments []segment
+		expect   string
+	}{
+		{[]segment{keySegment("foo"), keySegment("bar")}, "foo.bar"},
+		{[]segment{keySegment("foo"), indexSegment(1), keySegment("bar")}, "foo.1.bar"},
+		{[]segment{keySegment("hosts"), keySegment("example.com"), keySegment("port")}, `hosts["example.com"].port`},
+		{[]segment{keySegment("a.b")}, `["a.b"]`},
+		{[]segment{keySegment("a"), keySegment("404")}, `a["404"]`},
+		{[]segment{keySegment("a"), keySegment(`"q"\`)}, `a["\"q\"\\"]`},
+		{[]segment{keySegment("")}, `[""]`},
+		{[]segment{keySegment("a"), indexSegment(-1)}, `a.-1`},
+		{[]segment{keySegment("a"), keySegment("-1")}, `a["-1"]`},
+		{[]segment{keySegment("a"), rangeSegment(1, 3, false)}, `a[1:3]`},
+		{[]segment{keySegment("a"), rangeSegment(0, 3, false)}, `a[:3]`},
+		{[]segment{keySegment("a"), rangeSegment(-2, 0, true), keySegment("b")}, `a[-2:].b`},
+	}
+	for _, expect := range expects {
+		path := formatPath(expect.segments)
+		assert.Equal(t, expect.expect, path, "Segments should be formatted")
+		segments, err := parsePath(path)
+		assert.Nil(t, err, "Formatted path %s should parse", path)
+		assert.Equal(t, expect.segments, segments, "Formatted path %s should parse back", path)
+	}
+}
+
+func TestGPath_BracketPath(t *testing.T) {
+	gp := New(map[string]interface{}{
+		"users": []interface{}{
+			map[string]interface{}{"name": "alice", "roles": []string{"admin", "dev", "ops"}},
+		},
+		"codes": map[string]interface{}{
+			"404": map[string]interface{}{"text": "not found"},
+		},
+	})
+	assert.Equal(t, "ops", gp.GetString("users[0].roles[2]"))
+	assert.Equal(t, "ops", gp.GetString("users.0.roles.2"))
+	assert.Equal(t, "ops", gp.GetString("users[0].roles.2"))
+	assert.Equal(t, "alice", gp.GetString(`users[0]["name"]`))
+	assert.Equal(t, "not found", gp.GetString(`codes["404"].text`))
+	assert.False(t, gp.Has("codes.404"), "digits only are index, not key")
+	assert.False(t, gp.Has("users[1]"))
+	assert.False(t, gp.Has(`users["0"]`), "quoted digits are key, not index")
+
+	assert.Nil(t, gp.Set("users[0].roles[1]", "qa"))
+	assert.Equal(t, []string{"admin", "qa", "ops"}, gp.GetStrings("users.0.roles"))
+	assert.Nil(t, gp.Set(`codes["500"]`, "error"))
+	assert.Equal(t, "error", gp.GetString(`codes["500"]`))
+	assert.Nil(t, gp.Set(`codes.501`, "not implemented"), "index on map is written as key")
+	assert.Equal(t, "not implemented", gp.GetString(`codes["501"]`))
+}
+
+func TestGPath_EscapedPath(t *testing.T) {
+	gp := New(map[string]interface{}{
+		"hosts": map[string]interface{}{
+			"example.com": map[string]interface{}{"port": 8080},
+		},
+		"versions": map[string]interface{}{
+			"v1.2": []string{"a", "b"},
+		},
+	})
+	assert.True(t, gp.Has(`hosts["example.com"].port`))
+	assert.True(t, gp.Has(`hosts.example\.com.port`))
+	assert.False(t, gp.Has(`hosts.example.com.port`))
+	assert.Equal(t, int64(8080), gp.GetInt(`hosts["example.com"].port`))
+	assert.Equal(t, "8080", gp.GetString(`hosts.example\.com.port`))
+	assert.Equal(t, []string{"a", "b"}, gp.GetStrings(`versions["v1.2"]`))
+	assert.Equal(t, "b", gp.GetString(`versions.v1\.2.1`))
+	assert.False(t, gp.Has(`hosts["example.com"`), "malformed path is not found")
+
+	assert.Nil(t, gp.Set(`hosts["example.org"]`, map[string]interface{}{}))
+	assert.Nil(t, gp.Set(`hosts.example\.org.port`, 9090))
+	assert.Equal(t, int64(9090), gp.GetInt(`hosts["example.org"].port`))
+	assert.NotNil(t, gp.Set(`hosts["example.org"`, 1), "malformed path cannot be set")
+
+	assert.Nil(t, gp.Set(`hosts["example.com"].port`, 8081))
+	assert.Equal(t, int64(8081), gp.GetInt(`hosts.example\.com.port`), "cache is shared between notations")
+	assert.False(t, gp.Has("missing") || gp.Has("missing"), "repeated misses stay misses")
+}
+
+func Test_segmentBounds(t *testing.T) {
+	expects := []struct {
+		seg        segment
+		start, end int
+	}{
+		{rangeSegment(1, 4, false), 1, 4},
+		{rangeSegment(0, 3, false), 0, 3},
+		{rangeSegment(-2, 0, true), 3, 5},
+		{rangeSegment(0, 0, true), 0, 5},
+		{rangeSegment(1, -1, false), 1, 4},
+		{rangeSegment(3, 100, false), 3, 5},
+		{rangeSegment(-100, 2, false), 0, 2},
+		{rangeSegment(4, 2, false), 4, 4},
+		{rangeSegment(10, 0, true), 5, 5},
+	}
+	for _, expect := range expects {
+		start, end := expect.seg.bounds(5)
+		assert.Equal(t, []int{expect.start, expect.end}, []int{start, end}, "Bounds of %s", expect.seg)
+	}
+}
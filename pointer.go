@@ -0,0 +1,139 @@
+package gpath
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// HasPointer returns bool whether given JSON Pointer (RFC 6901) exists
+func (gp *GPath) HasPointer(pointer string) bool {
+	_, has := gp.getPointer(pointer)
+	return has
+}
+
+// GetPointer returns the value of the JSON Pointer (RFC 6901, eg `/users/0/name`) - or nil, if it
+// does not exist or is malformed. Tokens are unescaped (`~1` is "/" and `~0` is "~"), so keys
+// containing dots or slashes need no further quoting. Tokens applied to slices are indices, all others
+// keys. The empty pointer refers to the whole data structure.
+func (gp *GPath) GetPointer(pointer string) interface{} {
+	val, _ := gp.getPointer(pointer)
+	return val
+}
+
+// SetPointer works as Set, but with a JSON Pointer. If the last token is "-" and the parent is a slice,
+// the value is appended to the slice.
+func (gp *GPath) SetPointer(pointer string, value interface{}) error {
+	segments, err := gp.pointerSegments(pointer, true, false)
+	if err != nil {
+		return err
+	} else if len(segments) == 0 {
+		return fmt.Errorf("cannot write to pointer \"%s\", as it refers to the whole data structure", pointer)
+	}
+	return gp.set(segments, value)
+}
+
+func (gp *GPath) getPointer(pointer string) (interface{}, bool) {
+	segments, err := gp.pointerSegments(pointer, false, false)
+	if err != nil {
+		return nil, false
+	}
+	return gp.getSegments(segments)
+}
+
+// pointerSegments translates a JSON Pointer into path segments. Whether a token is an index or a key
+// depends on the value it is applied to, so the parents of the last token must exist. For writing,
+// the last token "-" on a slice becomes index -1 (append). With deep, parents may be missing (see
+// GPath.SetDeep): tokens below them are indices if numeric, otherwise keys, and "-" is the index past
+// the end of a slice, so that a new element is created.
+func (gp *GPath) pointerSegments(pointer string, write, deep bool) ([]segment, error) {
+	tokens, err := parsePointer(pointer)
+	if err != nil {
+		return nil, err
+	}
+	segments := make([]segment, 0, len(tokens))
+	cur := gp.source
+	for i, token := range tokens {
+		last := i == len(tokens)-1
+		seg := keySegment(token)
+		if cur == nil && deep {
+			if idx, ok := pointerIndex(token); ok {
+				seg = indexSegment(idx)
+			} else if token == "-" {
+				seg = indexSegment(0)
+			}
+		} else if ref := indirect(vof(cur)); ref.Kind() == reflect.Slice || ref.Kind() == reflect.Array {
+			if idx, ok := pointerIndex(token); ok {
+				seg = indexSegment(idx)
+			} else if token == "-" && last && write {
+				seg = indexSegment(-1)
+			} else if token == "-" && deep {
+				seg = indexSegment(ref.Len())
+			} else {
+				return nil, fmt.Errorf("invalid array index \"%s\" in pointer %s", token, pointer)
+			}
+		}
+		segments = append(segments, seg)
+		if last {
+			break
+		}
+		next, ok := getNext(seg, cur)
+		if !ok && deep {
+			next = nil
+		} else if !ok {
+			return nil, fmt.Errorf("parent element %s of pointer %s does not exist", formatPointer(segments), pointer)
+		}
+		cur = next
+	}
+	return segments, nil
+}
+
+// parsePointer splits a JSON Pointer into its unescaped reference tokens
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	} else if pointer[0] != '/' {
+		return nil, fmt.Errorf("pointer %s must start with \"/\"", pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		for pos := strings.IndexByte(token, '~'); pos > -1; {
+			if pos+1 >= len(token) || (token[pos+1] != '0' && token[pos+1] != '1') {
+				return nil, fmt.Errorf("invalid escape in token \"%s\" of pointer %s", token, pointer)
+			}
+			if next := strings.IndexByte(token[pos+2:], '~'); next > -1 {
+				pos += next + 2
+			} else {
+				pos = -1
+			}
+		}
+		tokens[i] = strings.Replace(strings.Replace(token, "~1", "/", -1), "~0", "~", -1)
+	}
+	return tokens, nil
+}
+
+// formatPointer returns the JSON Pointer of given concrete segments
+func formatPointer(segments []segment) string {
+	pointer := ""
+	for _, seg := range segments {
+		token := seg.key
+		if seg.kind == segmentIndex {
+			token = strconv.Itoa(seg.index)
+			if seg.index == -1 {
+				token = "-"
+			}
+		}
+		pointer += "/" + strings.Replace(strings.Replace(token, "~", "~0", -1), "/", "~1", -1)
+	}
+	return pointer
+}
+
+// pointerIndex returns the index of an array index token, which consists of digits without leading zeros
+func pointerIndex(token string) (int, bool) {
+	if !isUInt(token) || (len(token) > 1 && token[0] == '0') {
+		return 0, false
+	}
+	idx, err := strconv.Atoi(token)
+	return idx, err == nil
+}
@@ -0,0 +1,115 @@
+package gpath
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestGPath_GetPointer(t *testing.T) {
+	data := map[string]interface{}{
+		"users": []interface{}{
+			map[string]interface{}{"name": "alice"},
+			map[string]interface{}{"name": "bob"},
+		},
+		"a/b":          1,
+		"m~n":          2,
+		"example.com":  map[string]interface{}{"port": 443},
+		"":             3,
+		"0":            "zero",
+		"servers":      []testStructTLS{{Cert: "a.pem"}},
+		"nested-slice": [][]int{{1, 2}},
+	}
+	gp := New(data)
+	expects := []struct {
+		pointer string
+		expect  interface{}
+		has     bool
+	}{
+		{"", data, true},
+		{"/users/0/name", "alice", true},
+		{"/users/1/name", "bob", true},
+		{"/a~1b", 1, true},
+		{"/m~0n", 2, true},
+		{"/example.com/port", 443, true},
+		{"/", 3, true},
+		{"/0", "zero", true},
+		{"/servers/0/cert", "a.pem", true},
+		{"/servers/0/Cert", "a.pem", true},
+		{"/nested-slice/0/1", 2, true},
+		{"/users/2/name", nil, false},
+		{"/users/-/name", nil, false},
+		{"/users/-", nil, false},
+		{"/users/01/name", nil, false},
+		{"/users/-1/name", nil, false},
+		{"/users/name", nil, false},
+		{"/other", nil, false},
+		{"/m~2n", nil, false},
+		{"/m~", nil, false},
+		{"users", nil, false},
+	}
+	for _, expect := range expects {
+		assert.Equal(t, expect.expect, gp.GetPointer(expect.pointer), "GetPointer %s", expect.pointer)
+		assert.Equal(t, expect.has, gp.HasPointer(expect.pointer), "HasPointer %s", expect.pointer)
+	}
+}
+
+func TestGPath_SetPointer(t *testing.T) {
+	data := map[string]interface{}{
+		"users": []interface{}{
+			map[string]interface{}{"name": "alice"},
+		},
+		"a/b":   1,
+		"codes": map[string]interface{}{},
+	}
+	gp := New(data)
+
+	assert.Nil(t, gp.SetPointer("/users/0/name", "carol"))
+	assert.Equal(t, "carol", gp.Get("users.0.name"))
+
+	assert.Nil(t, gp.SetPointer("/users/-", map[string]interface{}{"name": "dave"}), "- appends")
+	assert.Equal(t, "dave", gp.GetPointer("/users/1/name"))
+
+	assert.Nil(t, gp.SetPointer("/a~1b", 2), "escaped key")
+	assert.Equal(t, 2, data["a/b"])
+
+	assert.Nil(t, gp.SetPointer("/codes/404", "not found"), "numeric token on map is key")
+	assert.Equal(t, "not found", gp.Get(`codes["404"]`))
+
+	assert.Nil(t, gp.SetPointer("/codes/-", "dash"), "- on map is key")
+	assert.Equal(t, "dash", gp.GetPointer("/codes/-"))
+
+	assert.NotNil(t, gp.SetPointer("", 1), "cannot replace whole data")
+	assert.NotNil(t, gp.SetPointer("/missing/key", 1), "parent must exist")
+	assert.NotNil(t, gp.SetPointer("/users/x", 1), "token on slice must be index")
+	assert.NotNil(t, gp.SetPointer("/users/5", 1), "index out of bounds")
+	assert.NotNil(t, gp.SetPointer("users", 1), "malformed pointer")
+}
+
+func Test_parsePointer(t *testing.T) {
+	expects := []struct {
+		pointer string
+		expect  []string
+		err     bool
+	}{
+		{"", nil, false},
+		{"/", []string{""}, false},
+		{"/a/b", []string{"a", "b"}, false},
+		{"/a~1b/~0", []string{"a/b", "~"}, false},
+		{"/~01", []string{"~1"}, false},
+		{"//", []string{"", ""}, false},
+		{"a", nil, true},
+		{"/~", nil, true},
+		{"/a~b", nil, true},
+	}
+	for _, expect := range expects {
+		tokens, err := parsePointer(expect.pointer)
+		assert.Equal(t, expect.expect, tokens, "Tokens of %s", expect.pointer)
+		assert.Equal(t, expect.err, err != nil, "Error of %s", expect.pointer)
+	}
+}
+
+func Test_formatPointer(t *testing.T) {
+	assert.Equal(t, "", formatPointer(nil))
+	assert.Equal(t, "/users/0/name", formatPointer([]segment{keySegment("users"), indexSegment(0), keySegment("name")}))
+	assert.Equal(t, "/a~1b/~0/-", formatPointer([]segment{keySegment("a/b"), keySegment("~"), indexSegment(-1)}))
+}
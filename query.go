@@ -0,0 +1,194 @@
+package gpath
+
+import (
+	"github.com/ukautz/cast"
+	"reflect"
+	"sort"
+)
+
+// Match is a single result of a query: the concrete path of a matching value and the value itself.
+// The path does not contain any wildcards and can be used with any other method, eg Set.
+type Match struct {
+	Path  string
+	Value interface{}
+}
+
+// Query returns all values matching the path, which can contain wildcards (`users.*.email`),
+// recursive descents (`**.password`), filters (`users[?@.active == true].email`, see ParsePredicate)
+// and ranges (`users[1:3].email`). Wildcards match every key of a map (in sorted order), every
+// element of a slice and every field of a struct. Recursive descents match the current value and all
+// of its descendants at any depth, so `**.password` finds every password key in the whole structure.
+// Returns nil if nothing matches or the path is malformed.
+func (gp *GPath) Query(path string) []Match {
+	segments, err := gp.parse(path, false)
+	if err != nil {
+		return nil
+	}
+	return gp.queryPath(segments)
+}
+
+// QueryValues returns the values of all matches of Query
+func (gp *GPath) QueryValues(path string) []interface{} {
+	matches := gp.Query(path)
+	if matches == nil {
+		return nil
+	}
+	values := make([]interface{}, len(matches))
+	for i, match := range matches {
+		values[i] = match.Value
+	}
+	return values
+}
+
+// QueryStrings returns the values of all matches of Query as slice of string, if each value can be
+// casted into string. Otherwise nil is returned.
+func (gp *GPath) QueryStrings(path string) []string {
+	if values := gp.QueryValues(path); values != nil {
+		return cast.CastStrings(values)
+	}
+	return nil
+}
+
+// QueryInts returns the values of all matches of Query as slice of int64, if each value can be
+// casted into int64. Otherwise nil is returned.
+func (gp *GPath) QueryInts(path string) []int64 {
+	if values := gp.QueryValues(path); values != nil {
+		return cast.CastInts(values)
+	}
+	return nil
+}
+
+// QueryFloats returns the values of all matches of Query as slice of float64, if each value can be
+// casted into float64. Otherwise nil is returned.
+func (gp *GPath) QueryFloats(path string) []float64 {
+	if values := gp.QueryValues(path); values != nil {
+		return cast.CastFloats(values)
+	}
+	return nil
+}
+
+// QueryBools returns the values of all matches of Query as slice of bool, if each value can be
+// casted into bool. Otherwise nil is returned.
+func (gp *GPath) QueryBools(path string) []bool {
+	if values := gp.QueryValues(path); values != nil {
+		return cast.CastBools(values)
+	}
+	return nil
+}
+
+// queryPath returns all matches of segments in the source, with paths in the notation of the GPath.
+// Multiple recursive descents can reach the same value on different ways, which is returned only once.
+func (gp *GPath) queryPath(segments []segment) []Match {
+	var matches []Match
+	seen := map[string]bool{}
+	followQuery(segments, gp.source, nil, map[visitKey]bool{}, func(concrete []segment, val interface{}) {
+		path := gp.format(concrete)
+		if !seen[path] {
+			seen[path] = true
+			matches = append(matches, Match{Path: path, Value: val})
+		}
+	})
+	return matches
+}
+
+// followQuery works as followPath, but expands wildcard and recursive segments. For each match, found
+// is called with the concrete segments leading to the value. Visiting contains the maps and pointers
+// which are currently descended into, to not run in circles.
+func followQuery(segments []segment, in interface{}, concrete []segment, visiting map[visitKey]bool, found func([]segment, interface{})) {
+	if len(segments) == 0 {
+		found(concrete, in)
+		return
+	}
+	cur, next := segments[0], segments[1:]
+	switch cur.kind {
+	case segmentWildcard:
+		for _, child := range children(in) {
+			followQuery(next, child.value, appendSegment(concrete, child.segment), visiting, found)
+		}
+	case segmentRange:
+		if ref := indirect(vof(in)); ref.Kind() == reflect.Slice {
+			start, end := cur.bounds(ref.Len())
+			for i := start; i < end; i++ {
+				followQuery(next, ref.Index(i).Interface(), appendSegment(concrete, indexSegment(i)), visiting, found)
+			}
+		}
+	case segmentFilter:
+		for _, child := range children(in) {
+			if cur.filter.Match(child.value) {
+				followQuery(next, child.value, appendSegment(concrete, child.segment), visiting, found)
+			}
+		}
+	case segmentRecursive:
+		ref := vof(in)
+		if k := ref.Kind(); k == reflect.Ptr || k == reflect.Map {
+			visit := visitKey{ref.Pointer(), len(segments)}
+			if visiting[visit] {
+				return
+			}
+			visiting[visit] = true
+			defer delete(visiting, visit)
+		}
+		followQuery(next, in, concrete, visiting, found)
+		for _, child := range children(in) {
+			followQuery(segments, child.value, appendSegment(concrete, child.segment), visiting, found)
+		}
+	default:
+		if cur.kind == segmentIndex && cur.index < 0 {
+			if ref := indirect(vof(in)); ref.Kind() == reflect.Slice {
+				cur = indexSegment(cur.index + ref.Len())
+			}
+		}
+		if val, ok := getNext(cur, in); ok {
+			followQuery(next, val, appendSegment(concrete, cur), visiting, found)
+		}
+	}
+}
+
+// visitKey identifies a map or pointer descended into by a recursive segment
+type visitKey struct {
+	pointer   uintptr
+	remaining int
+}
+
+// child is a direct descendant of a map, slice or struct
+type child struct {
+	segment segment
+	value   interface{}
+}
+
+// children returns all direct descendants of given map (keys in sorted order), slice or struct. Map
+// keys which are not strings are skipped, as they cannot be addressed by path.
+func children(in interface{}) []child {
+	ref := indirect(vof(in))
+	var res []child
+	switch ref.Kind() {
+	case reflect.Slice:
+		for i := 0; i < ref.Len(); i++ {
+			res = append(res, child{indexSegment(i), ref.Index(i).Interface()})
+		}
+	case reflect.Map:
+		for _, key := range ref.MapKeys() {
+			if name := indirect(key); name.Kind() == reflect.String {
+				res = append(res, child{keySegment(name.String()), ref.MapIndex(key).Interface()})
+			}
+		}
+		sort.Slice(res, func(i, j int) bool {
+			return res[i].segment.key < res[j].segment.key
+		})
+	case reflect.Struct:
+		for _, name := range structFieldNames(ref.Type()) {
+			if v := StructFieldValue(ref, name); v != nil {
+				res = append(res, child{keySegment(name), (*v).Interface()})
+			}
+		}
+	}
+	return res
+}
+
+// appendSegment returns a new slice of segments, which does not share memory with provided segments
+func appendSegment(segments []segment, seg segment) []segment {
+	res := make([]segment, len(segments)+1)
+	copy(res, segments)
+	res[len(segments)] = seg
+	return res
+}
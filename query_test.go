@@ -0,0 +1,132 @@
+package gpath
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+var _testQueryData = map[string]interface{}{
+	"users": []interface{}{
+		map[string]interface{}{"name": "alice", "email": "alice@example.com", "age": 30},
+		map[string]interface{}{"name": "bob", "age": "25"},
+		map[string]interface{}{"name": "carol", "email": "carol@example.com", "age": 35.5},
+	},
+	"groups": map[string]interface{}{
+		"admin": map[string]interface{}{"size": 2},
+		"dev":   map[string]interface{}{"size": 5},
+		"ops":   []string{"not", "a", "map"},
+	},
+	"servers": []testStructTLS{{Cert: "a.pem", Key: "a.key"}, {Cert: "b.pem"}},
+}
+
+func TestGPath_Query(t *testing.T) {
+	gp := New(_testQueryData)
+	expects := []struct {
+		path   string
+		expect []Match
+	}{
+		{"users.*.email", []Match{
+			{"users.0.email", "alice@example.com"},
+			{"users.2.email", "carol@example.com"},
+		}},
+		{"users[*].name", []Match{
+			{"users.0.name", "alice"},
+			{"users.1.name", "bob"},
+			{"users.2.name", "carol"},
+		}},
+		{"groups.*.size", []Match{
+			{"groups.admin.size", 2},
+			{"groups.dev.size", 5},
+		}},
+		{"servers.*.*", []Match{
+			{"servers.0.cert", "a.pem"},
+			{"servers.0.key_file", "a.key"},
+			{"servers.1.cert", "b.pem"},
+			{"servers.1.key_file", ""},
+		}},
+		{"users.-1.name", []Match{
+			{"users.2.name", "carol"},
+		}},
+		{"users.0.name", []Match{
+			{"users.0.name", "alice"},
+		}},
+		{"users.*.other", nil},
+		{"other.*", nil},
+		{"users.*.name.*", nil},
+		{"users[*", nil},
+	}
+	for _, expect := range expects {
+		assert.Equal(t, expect.expect, gp.Query(expect.path), "Query %s should match", expect.path)
+	}
+
+	for _, match := range gp.Query("users.*.name") {
+		assert.Equal(t, match.Value, gp.Get(match.Path), "Match path %s can be used with Get", match.Path)
+	}
+
+	gp = New(map[string]interface{}{"a.b": map[string]interface{}{"*": 1}})
+	assert.Equal(t, []Match{{`["a.b"]["*"]`, 1}}, gp.Query("*.*"), "Match paths are escaped")
+	assert.Equal(t, 1, gp.Get(`["a.b"]["*"]`), "Escaped match path can be used with Get")
+	assert.Nil(t, gp.Get("*.*"), "Get does not expand wildcards")
+	assert.NotNil(t, gp.Set("*", 1), "Set does not expand wildcards")
+}
+
+func TestGPath_QueryTyped(t *testing.T) {
+	gp := New(_testQueryData)
+	assert.Equal(t, []interface{}{"alice", "bob", "carol"}, gp.QueryValues("users.*.name"))
+	assert.Equal(t, []string{"alice@example.com", "carol@example.com"}, gp.QueryStrings("users.*.email"))
+	assert.Equal(t, []string{"30", "25", "35.5"}, gp.QueryStrings("users.*.age"))
+	assert.Equal(t, []int64{30, 25, 35}, gp.QueryInts("users.*.age"))
+	assert.Equal(t, []float64{30, 25, 35.5}, gp.QueryFloats("users.*.age"))
+	assert.Equal(t, []bool{true, true, true}, gp.QueryBools("users.*.age"))
+	assert.Nil(t, gp.QueryInts("users.*.name"), "not castable")
+	assert.Nil(t, gp.QueryStrings("users.*.other"), "no matches")
+}
+
+func TestGPath_QueryNamedKeys(t *testing.T) {
+	type name string
+	gp := New(map[name]int{"a": 1, "b": 2})
+	assert.Equal(t, []Match{{"a", 1}, {"b", 2}}, gp.Query("*"))
+	assert.Equal(t, 2, gp.Get("b"))
+}
+
+func TestGPath_QueryRecursive(t *testing.T) {
+	source := map[string]interface{}{
+		"password": "root",
+		"db": map[string]interface{}{
+			"user":     "app",
+			"password": "secret",
+			"replicas": []interface{}{
+				map[string]interface{}{"id": 1, "password": "r1"},
+				map[string]interface{}{"id": 2},
+			},
+		},
+		"tls": &testStructTLS{Cert: "cert.pem"},
+	}
+	gp := New(source)
+	assert.Equal(t, []Match{
+		{"password", "root"},
+		{"db.password", "secret"},
+		{"db.replicas.0.password", "r1"},
+	}, gp.Query("**.password"))
+	assert.Equal(t, []Match{
+		{"db.replicas.0.id", 1},
+		{"db.replicas.1.id", 2},
+	}, gp.Query("db.**.id"))
+	assert.Equal(t, []Match{{"tls.cert", "cert.pem"}}, gp.Query("**.cert"))
+	assert.Equal(t, []Match{
+		{"db.replicas.0.id", 1},
+		{"db.replicas.1.id", 2},
+	}, gp.Query("**.replicas.*.id"))
+	assert.Equal(t, gp.Query("**.password"), gp.Query("**.**.password"), "multiple recursions match once")
+	assert.Len(t, gp.Query("**"), 14, "recursion alone matches everything")
+
+	for _, match := range gp.Query("**.password") {
+		assert.Nil(t, gp.Set(match.Path, "***"), "Match path %s can be used with Set", match.Path)
+	}
+	assert.Equal(t, []string{"***", "***", "***"}, gp.QueryStrings("**.password"))
+	assert.Equal(t, "***", source["db"].(map[string]interface{})["password"])
+
+	cyclic := map[string]interface{}{"name": "root"}
+	cyclic["self"] = cyclic
+	assert.Equal(t, []Match{{"name", "root"}}, New(cyclic).Query("**.name"), "cycles are not followed")
+}
@@ -0,0 +1,165 @@
+package gpath
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// ResolveReason tells why a segment of a path could not be resolved, see ResolveError
+type ResolveReason int
+
+const (
+	// KeyMissing means that the map has no such key or the struct no such field
+	KeyMissing ResolveReason = iota
+
+	// IndexOutOfRange means that the slice has no element with such index
+	IndexOutOfRange
+
+	// ScalarValue means that the value is neither map, slice nor struct, so it has no children
+	ScalarValue
+
+	// KeyTypeMismatch means that the key cannot be used with the map, as the map has keys of another
+	// type (eg map[int]string)
+	KeyTypeMismatch
+
+	// NilPointer means that the value is a nil pointer or a nil interface
+	NilPointer
+
+	// ContainerMismatch means that a key was applied to a slice or an index to a map or struct
+	ContainerMismatch
+
+	// NotConcrete means that the segment is a wildcard, recursive descent or filter, which can match
+	// multiple values (see GPath.Query)
+	NotConcrete
+)
+
+// String returns a short description of the reason
+func (r ResolveReason) String() string {
+	switch r {
+	case KeyMissing:
+		return "key missing"
+	case IndexOutOfRange:
+		return "index out of range"
+	case ScalarValue:
+		return "value is a scalar"
+	case KeyTypeMismatch:
+		return "key type incompatible"
+	case NilPointer:
+		return "nil pointer"
+	case ContainerMismatch:
+		return "container mismatch"
+	case NotConcrete:
+		return "not concrete"
+	}
+	return "unknown"
+}
+
+// ResolveError describes at which segment and why the traversal of a path stopped
+type ResolveError struct {
+	// Path is the path which was resolved
+	Path string
+
+	// Parent is the path of the last value which could be resolved, empty for the root
+	Parent string
+
+	// Segment is the key or index (or wildcard, ..) which could not be resolved within the parent
+	Segment string
+
+	// Depth is the position of the segment within the path, starting at 0
+	Depth int
+
+	// Reason tells why the segment could not be resolved
+	Reason ResolveReason
+
+	// Detail describes the parent value, eg "slice of len 2"
+	Detail string
+}
+
+// Error returns a description of the failure, eg `cannot resolve users.5.name: index out of range at
+// segment "5" in users (slice of len 2)`
+func (e *ResolveError) Error() string {
+	parent := e.Parent
+	if parent == "" {
+		parent = "root"
+	}
+	return fmt.Sprintf("cannot resolve %s: %s at segment %q in %s (%s)", e.Path, e.Reason, e.Segment, parent, e.Detail)
+}
+
+// Resolve returns the value of the path or an error explaining why it could not be resolved. If the
+// path is malformed, a *PathError is returned. Otherwise, if the path does not exist, a *ResolveError
+// is returned, which names the segment where the traversal stopped and the reason.
+func (gp *GPath) Resolve(path string) (interface{}, error) {
+	segments, err := gp.parse(path, false)
+	if err != nil {
+		return nil, err
+	} else if val, ok := gp.getSegments(segments); ok {
+		return val, nil
+	}
+
+	in := gp.source
+	for depth, seg := range segments {
+		if val, ok := getNext(seg, in); ok {
+			in = val
+			continue
+		}
+		reason, detail := explainMiss(seg, in)
+		name := seg.String()
+		if seg.kind == segmentKey {
+			name = seg.key
+		}
+		return nil, &ResolveError{
+			Path:    path,
+			Parent:  gp.format(segments[0:depth]),
+			Segment: name,
+			Depth:   depth,
+			Reason:  reason,
+			Detail:  detail,
+		}
+	}
+	return in, nil
+}
+
+// explainMiss returns why getNext could not apply the segment to the value and a description of the
+// value
+func explainMiss(seg segment, in interface{}) (ResolveReason, string) {
+	if seg.kind != segmentKey && seg.kind != segmentIndex && seg.kind != segmentRange {
+		return NotConcrete, describeValue(vof(in))
+	}
+	ref := indirect(vof(in))
+	if !ref.IsValid() {
+		return NilPointer, describeValue(vof(in))
+	}
+	detail := describeValue(ref)
+	switch ref.Kind() {
+	case reflect.Map:
+		if seg.kind != segmentKey {
+			return ContainerMismatch, detail
+		} else if kk := ref.Type().Key().Kind(); kk != reflect.Interface && kk != reflect.String {
+			return KeyTypeMismatch, detail
+		}
+		return KeyMissing, detail
+	case reflect.Struct:
+		if seg.kind != segmentKey {
+			return ContainerMismatch, detail
+		}
+		return KeyMissing, detail
+	case reflect.Slice:
+		if seg.kind != segmentIndex {
+			return ContainerMismatch, detail
+		}
+		return IndexOutOfRange, detail
+	}
+	return ScalarValue, detail
+}
+
+// describeValue returns a short description of the value's type, eg "slice of len 2"
+func describeValue(ref reflect.Value) string {
+	if !ref.IsValid() {
+		return "nil"
+	}
+	switch ref.Kind() {
+	case reflect.Slice, reflect.Map:
+		return fmt.Sprintf("%s of len %d", ref.Type(), ref.Len())
+	}
+	return ref.Type().String()
+}
@@ -0,0 +1,78 @@
+package gpath
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestGPath_Resolve(t *testing.T) {
+	var nilMap *map[string]interface{}
+	gp := New(map[string]interface{}{
+		"users": []interface{}{
+			map[string]interface{}{"name": "alice"},
+			map[string]interface{}{"name": "bob"},
+		},
+		"codes":   map[int]string{404: "not found"},
+		"named":   map[string]interface{}{"a.b": 1},
+		"nil":     nilMap,
+		"server":  testStructTLS{Cert: "a.pem"},
+		"version": "1.2",
+	})
+
+	val, err := gp.Resolve("users.1.name")
+	assert.Nil(t, err)
+	assert.Equal(t, "bob", val)
+	val, err = gp.Resolve("users[0:1]")
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{map[string]interface{}{"name": "alice"}}, val)
+
+	expects := []struct {
+		path    string
+		parent  string
+		segment string
+		depth   int
+		reason  ResolveReason
+		detail  string
+	}{
+		{"users.5.name", "users", "5", 1, IndexOutOfRange, "[]interface {} of len 2"},
+		{"users.-3", "users", "-3", 1, IndexOutOfRange, "[]interface {} of len 2"},
+		{"users.0.email", "users.0", "email", 2, KeyMissing, "map[string]interface {} of len 1"},
+		{"other.x", "", "other", 0, KeyMissing, "map[string]interface {} of len 6"},
+		{"users.name", "users", "name", 1, ContainerMismatch, "[]interface {} of len 2"},
+		{"named.0", "named", "0", 1, ContainerMismatch, "map[string]interface {} of len 1"},
+		{"codes.404", "codes", "404", 1, ContainerMismatch, "map[int]string of len 1"},
+		{`codes["404"]`, "codes", "404", 1, KeyTypeMismatch, "map[int]string of len 1"},
+		{"nil.foo", "nil", "foo", 1, NilPointer, "*map[string]interface {}"},
+		{"version.major", "version", "major", 1, ScalarValue, "string"},
+		{"users.0.name.first", "users.0.name", "first", 3, ScalarValue, "string"},
+		{"server.other", "server", "other", 1, KeyMissing, "gpath.testStructTLS"},
+		{"server.0", "server", "0", 1, ContainerMismatch, "gpath.testStructTLS"},
+		{"users.*.name", "users", "*", 1, NotConcrete, "[]interface {} of len 2"},
+	}
+	for _, expect := range expects {
+		val, err := gp.Resolve(expect.path)
+		assert.Nil(t, val, "Path %s does not resolve", expect.path)
+		if assert.IsType(t, &ResolveError{}, err, "Path %s does not resolve", expect.path) {
+			rerr := err.(*ResolveError)
+			assert.Equal(t, expect.path, rerr.Path)
+			assert.Equal(t, expect.parent, rerr.Parent, "Parent of %s", expect.path)
+			assert.Equal(t, expect.segment, rerr.Segment, "Segment of %s", expect.path)
+			assert.Equal(t, expect.depth, rerr.Depth, "Depth of %s", expect.path)
+			assert.Equal(t, expect.reason, rerr.Reason, "Reason of %s", expect.path)
+			assert.Equal(t, expect.detail, rerr.Detail, "Detail of %s", expect.path)
+		}
+	}
+
+	_, err = gp.Resolve("users.5.name")
+	assert.EqualError(t, err, `cannot resolve users.5.name: index out of range at segment "5" in users ([]interface {} of len 2)`)
+	_, err = gp.Resolve("other")
+	assert.EqualError(t, err, `cannot resolve other: key missing at segment "other" in root (map[string]interface {} of len 6)`)
+	_, err = gp.Resolve("users[0")
+	assert.IsType(t, &PathError{}, err, "malformed path")
+
+	_, err = New(nil).Resolve("a")
+	assert.EqualError(t, err, `cannot resolve a: nil pointer at segment "a" in root (nil)`)
+
+	_, err = New(map[string]interface{}{"a": map[string]interface{}{}}, WithSeparator("/")).Resolve("a/b")
+	assert.Equal(t, "a", err.(*ResolveError).Parent, "parent in notation of GPath")
+}
@@ -41,19 +41,9 @@ func SliceIndexValueSet(theSlice reflect.Value, idx int, theValue reflect.Value,
 		return fmt.Errorf("provided index %d is out of bounds for slice of len %d", idx, actual.Len())
 	}
 
-	ek := actual.Type().Elem().Kind()
-	if ek != reflect.Interface {
-		if vk := theValue.Kind(); vk != ek {
-			if len(castFitting) > 0 && castFitting[0] {
-				if ref := cast.CastToValue(theValue, ek); ref != nil {
-					theValue = *ref
-				} else {
-					return fmt.Errorf("provided value is of %s kind and cannot be cast into %s kind", theValue.Kind(), ek)
-				}
-			} else {
-				return fmt.Errorf("provided value is of %s kind but must be %s kind", theValue.Kind(), ek)
-			}
-		}
+	theValue, err := fitSliceValue(actual, theValue, castFitting...)
+	if err != nil {
+		return err
 	}
 
 	if idx == -1 || idx == actual.Len() {
@@ -63,3 +53,87 @@ func SliceIndexValueSet(theSlice reflect.Value, idx int, theValue reflect.Value,
 	}
 	return nil
 }
+
+// SliceIndexDelete removes the element with provided index from the slice provided pointer points to. All
+// following elements are shifted. Returns bool whether the element was removed.
+func SliceIndexDelete(theSlice interface{}, idx int) bool {
+	return SliceIndexValueDelete(vof(theSlice), idx) == nil
+}
+
+// SliceIndexValueDelete works as SliceIndexDelete, but it expects reflect.Value parameter and returns specific
+// error why element could not be removed.
+func SliceIndexValueDelete(theSlice reflect.Value, idx int) error {
+	actual := reflect.Indirect(theSlice)
+	if theSlice.Kind() != reflect.Ptr || actual.Kind() != reflect.Slice {
+		return fmt.Errorf("expected pointer to Slice but got %s (indirect: %s)", theSlice.Kind(), actual.Kind())
+	} else if idx < 0 || idx >= actual.Len() {
+		return fmt.Errorf("provided index %d is out of bounds for slice of len %d", idx, actual.Len())
+	}
+
+	// shift following elements, then zero the now unused last element, so it can be garbage collected
+	last := actual.Len() - 1
+	reflect.Copy(actual.Slice(idx, last), actual.Slice(idx+1, last+1))
+	actual.Index(last).Set(reflect.Zero(actual.Type().Elem()))
+	actual.SetLen(last)
+	return nil
+}
+
+// SliceIndexInsert inserts provided value at provided index into the slice provided pointer points to. The
+// element at the index and all following are shifted. Index len(slice) appends. Returns bool whether the value
+// was inserted.
+func SliceIndexInsert(theSlice interface{}, idx int, theValue interface{}, castFitting ...bool) bool {
+	return SliceIndexValueInsert(vof(theSlice), idx, vof(theValue), castFitting...) == nil
+}
+
+// SliceIndexValueInsert works as SliceIndexInsert, but it expects reflect.Value parameters and returns specific
+// error why value could not be inserted.
+func SliceIndexValueInsert(theSlice reflect.Value, idx int, theValue reflect.Value, castFitting ...bool) error {
+	actual := reflect.Indirect(theSlice)
+	if theSlice.Kind() != reflect.Ptr || actual.Kind() != reflect.Slice {
+		return fmt.Errorf("expected pointer to Slice but got %s (indirect: %s)", theSlice.Kind(), actual.Kind())
+	} else if idx < 0 || idx > actual.Len() {
+		return fmt.Errorf("provided index %d is out of bounds for slice of len %d", idx, actual.Len())
+	}
+	theValue, err := fitSliceValue(actual, theValue, castFitting...)
+	if err != nil {
+		return err
+	}
+
+	// grow by one, shift following elements, then put value in the gap
+	last := actual.Len()
+	actual.Set(reflect.Append(actual, reflect.Zero(actual.Type().Elem())))
+	reflect.Copy(actual.Slice(idx+1, last+1), actual.Slice(idx, last))
+	actual.Index(idx).Set(theValue)
+	return nil
+}
+
+// sliceCopy returns a copy of the slice, which does not share the backing array with the original
+func sliceCopy(theSlice reflect.Value) reflect.Value {
+	cp := reflect.MakeSlice(theSlice.Type(), theSlice.Len(), theSlice.Len())
+	reflect.Copy(cp, theSlice)
+	return cp
+}
+
+// fitSliceValue returns the value as it can be stored in the slice, casted to the kind of elements if
+// castFitting is enabled
+func fitSliceValue(actual reflect.Value, theValue reflect.Value, castFitting ...bool) (reflect.Value, error) {
+	ek := actual.Type().Elem().Kind()
+	if !theValue.IsValid() {
+		switch ek {
+		case reflect.Interface, reflect.Ptr, reflect.Map, reflect.Slice:
+			return reflect.Zero(actual.Type().Elem()), nil
+		}
+		return theValue, fmt.Errorf("provided value is nil but must be %s kind", ek)
+	} else if ek != reflect.Interface {
+		if vk := theValue.Kind(); vk != ek {
+			if len(castFitting) > 0 && castFitting[0] {
+				if ref := cast.CastToValue(theValue, ek); ref != nil {
+					return *ref, nil
+				}
+				return theValue, fmt.Errorf("provided value is of %s kind and cannot be cast into %s kind", theValue.Kind(), ek)
+			}
+			return theValue, fmt.Errorf("provided value is of %s kind but must be %s kind", theValue.Kind(), ek)
+		}
+	}
+	return theValue, nil
+}
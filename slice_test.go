@@ -118,3 +118,46 @@ func TestSliceIndexSet(t *testing.T) {
 	assert.False(t, SliceIndexSet("bla", -1, "foo"), "Appending to not-slice (string) fails")
 	assert.False(t, SliceIndexSet(map[string]interface{}{}, -1, "foo"), "Appending to not-slice (map) fails")
 }
+
+func TestSliceIndexDelete(t *testing.T) {
+	ss := []string{"a", "b", "c", "d"}
+	backing := ss
+	assert.True(t, SliceIndexDelete(&ss, 1), "Removing of existing element should work")
+	assert.Equal(t, []string{"a", "c", "d"}, ss, "Following elements are shifted")
+	assert.Equal(t, "", backing[3], "Unused element is zeroed")
+
+	assert.True(t, SliceIndexDelete(&ss, 2), "Removing of last element should work")
+	assert.Equal(t, []string{"a", "c"}, ss, "Last element removed")
+
+	assert.False(t, SliceIndexDelete(&ss, 2), "Removing of out of bounds index fails")
+	assert.False(t, SliceIndexDelete(&ss, -1), "Removing of negative index fails")
+	assert.False(t, SliceIndexDelete(ss, 0), "Removing from slice, not pointer to slice, fails")
+	assert.False(t, SliceIndexDelete(map[string]interface{}{}, 0), "Removing from not-slice (map) fails")
+	assert.Equal(t, []string{"a", "c"}, ss, "Unchanged after failures")
+
+	assert.True(t, SliceIndexDelete(&ss, 0))
+	assert.True(t, SliceIndexDelete(&ss, 0))
+	assert.Equal(t, []string{}, ss, "All elements removed")
+}
+
+func TestSliceIndexInsert(t *testing.T) {
+	ss := []string{"a", "c"}
+	assert.True(t, SliceIndexInsert(&ss, 1, "b"), "Inserting of right value should work")
+	assert.Equal(t, []string{"a", "b", "c"}, ss, "Following elements are shifted")
+	assert.True(t, SliceIndexInsert(&ss, 0, "0"), "Inserting at start should work")
+	assert.True(t, SliceIndexInsert(&ss, 4, "d"), "Inserting at len appends")
+	assert.Equal(t, []string{"0", "a", "b", "c", "d"}, ss)
+
+	assert.False(t, SliceIndexInsert(&ss, 6, "x"), "Inserting at out of bounds index fails")
+	assert.False(t, SliceIndexInsert(&ss, -1, "x"), "Inserting at negative index fails")
+	assert.False(t, SliceIndexInsert(&ss, 0, 123), "Inserting wrong type fails")
+	assert.False(t, SliceIndexInsert(ss, 0, "x"), "Inserting into slice, not pointer to slice, fails")
+	assert.Equal(t, []string{"0", "a", "b", "c", "d"}, ss, "Unchanged after failures")
+
+	assert.True(t, SliceIndexInsert(&ss, 0, 123, true), "Inserting wrong type with casting works")
+	assert.Equal(t, "123", ss[0])
+
+	ii := []interface{}{}
+	assert.True(t, SliceIndexInsert(&ii, 0, nil), "Interface slice accepts nil")
+	assert.Equal(t, []interface{}{nil}, ii)
+}
@@ -0,0 +1,197 @@
+package gpath
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// Insert inserts the value into the slice of the path before the element with the index, which and all
+// following elements are shifted. Negative indices count from the end of the slice, so -1 appends the
+// value and -2 inserts it before the last element.
+func (gp *GPath) Insert(path string, idx int, value interface{}) error {
+	return gp.modifySlice(path, func(ref reflect.Value) error {
+		if idx < 0 {
+			idx += ref.Elem().Len() + 1
+		}
+		return SliceIndexValueInsert(ref, idx, vof(value), true)
+	})
+}
+
+// RemoveAt removes the element with the index from the slice of the path, all following elements are
+// shifted. Negative indices count from the end of the slice (-1 is the last element).
+func (gp *GPath) RemoveAt(path string, idx int) error {
+	return gp.modifySlice(path, func(ref reflect.Value) error {
+		idx, err := sliceIndex(ref.Elem(), idx)
+		if err != nil {
+			return err
+		}
+		return SliceIndexValueDelete(ref, idx)
+	})
+}
+
+// Move moves the element with the index from to the index to within the slice of the path, the elements
+// in between are shifted. Negative indices count from the end of the slice, so Move("steps", 0, -1) makes
+// the first step the last.
+func (gp *GPath) Move(path string, from, to int) error {
+	return gp.modifySlice(path, func(ref reflect.Value) error {
+		from, err := sliceIndex(ref.Elem(), from)
+		if err != nil {
+			return err
+		}
+		to, err := sliceIndex(ref.Elem(), to)
+		if err != nil {
+			return err
+		}
+		moved := reflect.New(ref.Elem().Type().Elem()).Elem()
+		moved.Set(ref.Elem().Index(from))
+		if err := SliceIndexValueDelete(ref, from); err != nil {
+			return err
+		}
+		return SliceIndexValueInsert(ref, to, moved)
+	})
+}
+
+// Swap exchanges the elements with the indices i and j within the slice of the path. Negative indices
+// count from the end of the slice.
+func (gp *GPath) Swap(path string, i, j int) error {
+	return gp.modifySlice(path, func(ref reflect.Value) error {
+		i, err := sliceIndex(ref.Elem(), i)
+		if err != nil {
+			return err
+		}
+		j, err := sliceIndex(ref.Elem(), j)
+		if err != nil {
+			return err
+		}
+		a, b := ref.Elem().Index(i), ref.Elem().Index(j)
+		tmp := reflect.New(a.Type()).Elem()
+		tmp.Set(a)
+		a.Set(b)
+		b.Set(tmp)
+		return nil
+	})
+}
+
+// Append appends the values to the slice of the path. Each value is casted into the kind of the elements
+// of the slice, if necessary. If the path does not exist (or is nil), a new slice is created along with
+// missing parents, see SetDeep and WithContainers.
+func (gp *GPath) Append(path string, values ...interface{}) error {
+	return gp.extendSlice(path, values, func(slice reflect.Value, add []reflect.Value) reflect.Value {
+		return reflect.Append(slice, add...)
+	})
+}
+
+// Prepend works as Append, but puts the values in front of the existing elements of the slice, in the
+// order given.
+func (gp *GPath) Prepend(path string, values ...interface{}) error {
+	return gp.extendSlice(path, values, func(slice reflect.Value, add []reflect.Value) reflect.Value {
+		res := reflect.MakeSlice(slice.Type(), 0, slice.Len()+len(add))
+		return reflect.AppendSlice(reflect.Append(res, add...), slice)
+	})
+}
+
+// extendSlice creates the slice of the path, if missing, casts the values into the kind of its elements
+// and replaces the slice with the result of fn. Nothing is changed, if any value cannot be casted.
+func (gp *GPath) extendSlice(path string, values []interface{}, fn func(slice reflect.Value, add []reflect.Value) reflect.Value) error {
+	segments, err := gp.parseDeep(path)
+	if err != nil {
+		return err
+	} else if val, has := gp.getSegments(segments); !has || val == nil {
+		// cast before anything is created, so that a failing cast does not leave an empty slice behind
+		slice := vof(gp.containers.newSlice())
+		add, err := fitSliceValues(slice, values)
+		if err != nil {
+			return fmt.Errorf("could not modify %s (%s)", gp.format(segments), err)
+		}
+		return gp.setDeep(segments, fn(slice, add).Interface())
+	}
+	return gp.modifySliceSegments(segments, func(ref reflect.Value) error {
+		add, err := fitSliceValues(ref.Elem(), values)
+		if err != nil {
+			return err
+		}
+		ref.Elem().Set(fn(ref.Elem(), add))
+		return nil
+	})
+}
+
+// fitSliceValues casts all values into the kind of the elements of the slice
+func fitSliceValues(slice reflect.Value, values []interface{}) ([]reflect.Value, error) {
+	add := make([]reflect.Value, len(values))
+	for i, value := range values {
+		fitted, err := fitSliceValue(slice, vof(value), true)
+		if err != nil {
+			return nil, err
+		}
+		add[i] = fitted
+	}
+	return add, nil
+}
+
+// modifySlice applies fn to a pointer to the slice of the path. Slices which are not referenced by a
+// pointer in the data structure are written back into their parent afterwards (see Set). As indices
+// shift, all cached values below the slice are cleared.
+func (gp *GPath) modifySlice(path string, fn func(ref reflect.Value) error) error {
+	segments, err := gp.parse(path, false)
+	if err != nil {
+		return err
+	}
+	return gp.modifySliceSegments(segments, fn)
+}
+
+func (gp *GPath) modifySliceSegments(segments []segment, fn func(ref reflect.Value) error) error {
+	if !isConcrete(segments) {
+		return fmt.Errorf("cannot modify %s, as it does not address a single element", gp.format(segments))
+	}
+	val, has := gp.getSegments(segments)
+	if !has {
+		return ErrNotFound
+	}
+
+	// find pointer to slice: either referenced in data structure (modify in place) or a copy which must
+	// be written back, so that nothing changes if the write back fails
+	ref := vof(val)
+	isref := ref.Kind() == reflect.Ptr
+	if isref {
+		for ref.Elem().Kind() == reflect.Ptr {
+			if ref.Elem().IsNil() {
+				return fmt.Errorf("could not modify %s (nil pointer)", gp.format(segments))
+			}
+			ref = ref.Elem()
+		}
+	} else if ref.Kind() == reflect.Slice {
+		if len(segments) == 0 {
+			return errors.New("parent element cannot be slice. Provide either pointer to slice or slice embedded within maps")
+		}
+		ptr := reflect.New(ref.Type())
+		ptr.Elem().Set(sliceCopy(ref))
+		ref = ptr
+	}
+	if ref.Kind() != reflect.Ptr || ref.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("could not modify %s, as it is not a slice (%s)", gp.format(segments), indirect(vof(val)).Kind())
+	}
+
+	if err := fn(ref); err != nil {
+		return fmt.Errorf("could not modify %s (%s)", gp.format(segments), err)
+	} else if !isref {
+		return gp.set(segments, ref.Elem().Interface())
+	} else if cacheable(segments) {
+		gp.clearBelow(segments)
+	} else {
+		gp.clearBelow(nil)
+	}
+	return nil
+}
+
+// sliceIndex returns the index within the slice, with negative indices counting from the end
+func sliceIndex(slice reflect.Value, idx int) (int, error) {
+	abs := idx
+	if abs < 0 {
+		abs += slice.Len()
+	}
+	if abs < 0 || abs >= slice.Len() {
+		return abs, fmt.Errorf("provided index %d is out of bounds for slice of len %d", idx, slice.Len())
+	}
+	return abs, nil
+}
@@ -0,0 +1,167 @@
+package gpath
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func _testSpliceData() map[string]interface{} {
+	return map[string]interface{}{
+		"steps": []interface{}{"build", "test", "deploy"},
+		"ports": []int{80, 443},
+		"nested": map[string]interface{}{
+			"list": []string{"a", "b", "c", "d"},
+		},
+		"name": "pipeline",
+	}
+}
+
+func TestGPath_Insert(t *testing.T) {
+	data := _testSpliceData()
+	gp := New(data)
+	expects := []struct {
+		path   string
+		idx    int
+		value  interface{}
+		expect interface{}
+	}{
+		{"steps", 1, "lint", []interface{}{"build", "lint", "test", "deploy"}},
+		{"steps", 0, "checkout", []interface{}{"checkout", "build", "lint", "test", "deploy"}},
+		{"steps", -1, "notify", []interface{}{"checkout", "build", "lint", "test", "deploy", "notify"}},
+		{"steps", -2, "smoke", []interface{}{"checkout", "build", "lint", "test", "deploy", "smoke", "notify"}},
+		{"ports", 2, "8080", []int{80, 443, 8080}},
+		{"nested.list", 2, "x", []string{"a", "b", "x", "c", "d"}},
+	}
+	for _, expect := range expects {
+		assert.Equal(t, "pipeline", gp.Get("name"))
+		assert.Nil(t, gp.Insert(expect.path, expect.idx, expect.value), "Insert into %s at %d", expect.path, expect.idx)
+		assert.Equal(t, expect.expect, gp.Get(expect.path), "Insert into %s at %d", expect.path, expect.idx)
+	}
+	assert.Equal(t, []int{80, 443, 8080}, data["ports"], "slice is written back into map")
+
+	assert.NotNil(t, gp.Insert("steps", 10, "x"), "out of bounds")
+	assert.NotNil(t, gp.Insert("ports", 0, "abc"), "uncastable value")
+	assert.NotNil(t, gp.Insert("name", 0, "x"), "not a slice")
+	assert.NotNil(t, gp.Insert("*", 0, "x"), "not concrete")
+	assert.Equal(t, ErrNotFound, gp.Insert("other", 0, "x"))
+	assert.Nil(t, gp.Insert("steps", 0, nil), "nil into interface slice")
+	assert.Nil(t, gp.Get("steps.0"))
+}
+
+func TestGPath_RemoveAt(t *testing.T) {
+	data := _testSpliceData()
+	gp := New(data)
+	assert.Equal(t, "test", gp.Get("steps.1"), "value is cached")
+	assert.Equal(t, "deploy", gp.Get("steps.2"), "value is cached")
+	assert.Nil(t, gp.RemoveAt("steps", 1))
+	assert.Equal(t, "deploy", gp.Get("steps.1"), "cache of shifted index is cleared")
+	assert.False(t, gp.Has("steps.2"), "cache of removed index is cleared")
+	assert.Equal(t, []interface{}{"build", "deploy"}, data["steps"])
+
+	assert.Nil(t, gp.RemoveAt("nested.list", -1))
+	assert.Equal(t, []string{"a", "b", "c"}, gp.Get("nested.list"))
+	assert.EqualError(t, gp.RemoveAt("ports", 2), "could not modify ports (provided index 2 is out of bounds for slice of len 2)")
+	assert.EqualError(t, gp.RemoveAt("ports", -3), "could not modify ports (provided index -3 is out of bounds for slice of len 2)")
+
+	root := []interface{}{1, 2, 3}
+	assert.Nil(t, New(&root, WithDialect(Pointer)).RemoveAt("", 0), "pointer to slice is modified in place")
+	assert.Equal(t, []interface{}{2, 3}, root)
+	assert.NotNil(t, New(root, WithDialect(Pointer)).RemoveAt("", 0), "root slice must be given as pointer")
+
+	pipeline := testStructPipeline{Steps: []string{"a", "b", "c"}}
+	assert.EqualError(t, New(pipeline).RemoveAt("Steps", 0), "parent element cannot be struct. Provide either pointer to struct or struct embedded within maps or slices")
+	assert.Equal(t, []string{"a", "b", "c"}, pipeline.Steps, "slice in struct is unchanged, if it cannot be written back")
+	assert.Nil(t, New(&pipeline).RemoveAt("Steps", 0), "slice in struct is written back into field")
+	assert.Equal(t, []string{"b", "c"}, pipeline.Steps)
+}
+
+func TestGPath_Move(t *testing.T) {
+	gp := New(_testSpliceData())
+	expects := []struct {
+		from   int
+		to     int
+		expect []string
+	}{
+		{0, 2, []string{"b", "c", "a", "d"}},
+		{2, 0, []string{"a", "b", "c", "d"}},
+		{0, -1, []string{"b", "c", "d", "a"}},
+		{-1, 1, []string{"b", "a", "c", "d"}},
+		{1, 1, []string{"b", "a", "c", "d"}},
+	}
+	for _, expect := range expects {
+		gp.Get("nested.list.0")
+		assert.Nil(t, gp.Move("nested.list", expect.from, expect.to), "Move %d to %d", expect.from, expect.to)
+		assert.Equal(t, expect.expect, gp.GetStrings("nested.list"), "Move %d to %d", expect.from, expect.to)
+		assert.Equal(t, expect.expect[0], gp.Get("nested.list.0"), "cache is cleared")
+	}
+	assert.NotNil(t, gp.Move("nested.list", 0, 4), "out of bounds")
+	assert.NotNil(t, gp.Move("nested.list", 4, 0), "out of bounds")
+}
+
+func TestGPath_Swap(t *testing.T) {
+	items := []interface{}{"x", "y", "z"}
+	gp := New(map[string]interface{}{"items": &items, "steps": []interface{}{"build", "test"}})
+	assert.Equal(t, "x", gp.Get("items.0"))
+	assert.Nil(t, gp.Swap("items", 0, -1))
+	assert.Equal(t, []interface{}{"z", "y", "x"}, items, "pointer to slice is modified in place")
+	assert.Equal(t, "z", gp.Get("items.0"), "cache is cleared")
+	assert.Nil(t, gp.Swap("steps", 1, 0))
+	assert.Equal(t, []interface{}{"test", "build"}, gp.Get("steps"))
+	assert.NotNil(t, gp.Swap("steps", 0, 2))
+
+	pipeline := testStructPipeline{Steps: []string{"a", "b", "c"}}
+	assert.EqualError(t, New(pipeline).Swap("Steps", 0, 2), "parent element cannot be struct. Provide either pointer to struct or struct embedded within maps or slices")
+	assert.Equal(t, []string{"a", "b", "c"}, pipeline.Steps, "slice in struct is unchanged, if it cannot be written back")
+	assert.Nil(t, New(&pipeline).Swap("Steps", 0, 2), "slice in struct is written back into field")
+	assert.Equal(t, []string{"c", "b", "a"}, pipeline.Steps)
+}
+
+func TestGPath_Append(t *testing.T) {
+	data := _testSpliceData()
+	gp := New(data)
+	expects := []struct {
+		path   string
+		values []interface{}
+		expect interface{}
+	}{
+		{"steps", []interface{}{"notify"}, []interface{}{"build", "test", "deploy", "notify"}},
+		{"ports", []interface{}{8080, "8443", 9000.0}, []int{80, 443, 8080, 8443, 9000}},
+		{"warnings", []interface{}{"a", "b"}, []interface{}{"a", "b"}},
+		{"warnings", []interface{}{"c"}, []interface{}{"a", "b", "c"}},
+		{"doc.meta.tags", []interface{}{"x"}, []interface{}{"x"}},
+		{"empty", nil, []interface{}{}},
+	}
+	for _, expect := range expects {
+		assert.Nil(t, gp.Append(expect.path, expect.values...), "Append to %s", expect.path)
+		assert.Equal(t, expect.expect, gp.Get(expect.path), "Append to %s", expect.path)
+	}
+	assert.Equal(t, []interface{}{"a", "b", "c"}, data["warnings"], "created slice is in data")
+	assert.Equal(t, "x", gp.Get("doc.meta.tags.0"))
+
+	assert.NotNil(t, gp.Append("ports", 1, "abc"), "uncastable value")
+	assert.Equal(t, []int{80, 443, 8080, 8443, 9000}, gp.Get("ports"), "nothing is appended if any value cannot be casted")
+	assert.NotNil(t, gp.Append("name", "x"), "not a slice")
+	assert.NotNil(t, gp.Append("name.x", "x"), "parent is a scalar")
+
+	tgp := New(map[string]interface{}{}, WithContainers(nil, []string{}))
+	assert.Nil(t, tgp.Append("tags", "a", 1))
+	assert.Equal(t, []string{"a", "1"}, tgp.Get("tags"), "created slice is of configured type")
+
+	igp := New(map[string]interface{}{}, WithContainers(nil, []int{}))
+	assert.EqualError(t, igp.Append("a.b", "xyz"), "could not modify a.b (provided value is of string kind and cannot be cast into int kind)")
+	assert.False(t, igp.Has("a"), "nothing is created if any value cannot be casted")
+}
+
+func TestGPath_Prepend(t *testing.T) {
+	data := _testSpliceData()
+	gp := New(data)
+	assert.Equal(t, "build", gp.Get("steps.0"))
+	assert.Nil(t, gp.Prepend("steps", "checkout", "setup"))
+	assert.Equal(t, []interface{}{"checkout", "setup", "build", "test", "deploy"}, data["steps"])
+	assert.Equal(t, "checkout", gp.Get("steps.0"), "cache is cleared")
+	assert.Nil(t, gp.Prepend("nested.list", "0"))
+	assert.Equal(t, []string{"0", "a", "b", "c", "d"}, gp.Get("nested.list"))
+	assert.Nil(t, gp.Prepend("new", 1))
+	assert.Equal(t, []interface{}{1}, gp.Get("new"))
+	assert.NotNil(t, gp.Prepend("ports", "abc"))
+}
@@ -0,0 +1,276 @@
+package gpath
+
+import (
+	"fmt"
+	"github.com/ukautz/cast"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// structTags is the ordered list of struct tags which can rename a field for path access
+var structTags = []string{"gpath", "json", "yaml"}
+
+// structField describes how to reach a (possibly promoted) field within a struct
+type structField struct {
+	index  []int
+	name   string
+	tagged bool
+}
+
+// structFieldsCache holds the resolved field names per struct type (I want to support Go < 1.9, so no sync.Map ..)
+var structFieldsCache = struct {
+	data map[reflect.Type]map[string]structField
+	mux  sync.RWMutex
+}{data: map[reflect.Type]map[string]structField{}}
+
+// StructField returns value of the exported field with given name in provided struct. The name
+// can be either the Go field name or the name given in a `gpath`, `json` or `yaml` struct tag. Fields
+// of embedded structs are promoted as encoding/json does. Second return parameter is false, if no
+// such field exists (or provided struct is not actually a struct)
+func StructField(theStruct interface{}, name string) (interface{}, bool) {
+	if v := StructFieldValue(vof(theStruct), name); v == nil {
+		return nil, false
+	} else {
+		return (*v).Interface(), true
+	}
+}
+
+// StructFieldValue returns pointer to reflect.Value of the field with given name in provided struct
+// or nil, if the field does not exist, is only reachable through a nil embedded pointer or provided
+// struct is not actually a struct
+func StructFieldValue(theStruct reflect.Value, name string) *reflect.Value {
+	if theStruct.Kind() != reflect.Struct {
+		return nil
+	}
+	field, ok := structFields(theStruct.Type())[name]
+	if !ok {
+		return nil
+	}
+	v := theStruct
+	for i, idx := range field.index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return nil
+			}
+			v = v.Elem()
+		}
+		v = v.Field(idx)
+	}
+	return &v
+}
+
+// StructFieldSet sets the value of the exported field with given name (see StructField) in the struct
+// provided pointer points to. Returns bool whether the value could be set. If castFitting is enabled,
+// the value is casted into the kind of the field.
+func StructFieldSet(theStruct interface{}, name string, theValue interface{}, castFitting ...bool) bool {
+	return StructFieldValueSet(vof(theStruct), name, vof(theValue), castFitting...) == nil
+}
+
+// StructFieldValueSet works as StructFieldSet, but it expects reflect.Value parameters and returns
+// specific error why the value could not be set.
+func StructFieldValueSet(theStruct reflect.Value, name string, theValue reflect.Value, castFitting ...bool) error {
+	actual := reflect.Indirect(theStruct)
+	if theStruct.Kind() != reflect.Ptr || actual.Kind() != reflect.Struct {
+		return fmt.Errorf("expected pointer to Struct but got %s (indirect: %s)", theStruct.Kind(), actual.Kind())
+	}
+	field := StructFieldValue(actual, name)
+	if field == nil {
+		return fmt.Errorf("provided struct has no field %s", name)
+	} else if !field.CanSet() {
+		return fmt.Errorf("field %s of provided struct is not writable", name)
+	}
+	theValue, err := fitStructValue(*field, theValue, castFitting...)
+	if err != nil {
+		return err
+	}
+	field.Set(theValue)
+	return nil
+}
+
+// fitStructValue returns the value as it can be stored in the field, casted to the kind of the field if
+// castFitting is enabled
+func fitStructValue(field, theValue reflect.Value, castFitting ...bool) (reflect.Value, error) {
+	ft := field.Type()
+	if !theValue.IsValid() {
+		switch ft.Kind() {
+		case reflect.Interface, reflect.Ptr, reflect.Map, reflect.Slice:
+			return reflect.Zero(ft), nil
+		}
+		return theValue, fmt.Errorf("provided value is nil but must be %s kind", ft.Kind())
+	} else if theValue.Type().AssignableTo(ft) {
+		return theValue, nil
+	} else if theValue.Kind() == ft.Kind() && theValue.Type().ConvertibleTo(ft) {
+		return theValue.Convert(ft), nil
+	} else if len(castFitting) > 0 && castFitting[0] {
+		if ref := cast.CastToValue(theValue, ft.Kind()); ref != nil && ref.Type().ConvertibleTo(ft) {
+			return ref.Convert(ft), nil
+		}
+		return theValue, fmt.Errorf("provided value is of %s kind and cannot be cast into %s kind", theValue.Kind(), ft.Kind())
+	}
+	return theValue, fmt.Errorf("provided value is of %s kind but must be %s kind", theValue.Kind(), ft.Kind())
+}
+
+// structFields returns all accessible field names of given struct type, including promoted fields of
+// embedded structs. Conflicts are resolved like encoding/json does: the shallowest field wins, then
+// the only tagged field of that depth. Otherwise the name is ambiguous and left out.
+func structFields(t reflect.Type) map[string]structField {
+	structFieldsCache.mux.RLock()
+	fields, ok := structFieldsCache.data[t]
+	structFieldsCache.mux.RUnlock()
+	if ok {
+		return fields
+	}
+
+	type candidate struct {
+		structField
+		depth int
+	}
+	candidates := map[string][]candidate{}
+	type level struct {
+		typ   reflect.Type
+		index []int
+	}
+	current := []level{{typ: t}}
+	visited := map[reflect.Type]bool{}
+	for depth := 0; len(current) > 0; depth++ {
+		next := []level{}
+		for _, l := range current {
+			if visited[l.typ] {
+				continue
+			}
+			visited[l.typ] = true
+			for i := 0; i < l.typ.NumField(); i++ {
+				f := l.typ.Field(i)
+				index := make([]int, len(l.index)+1)
+				copy(index, l.index)
+				index[len(l.index)] = i
+
+				ft := f.Type
+				if ft.Kind() == reflect.Ptr {
+					ft = ft.Elem()
+				}
+				exported := f.PkgPath == ""
+				if !exported && !(f.Anonymous && ft.Kind() == reflect.Struct) {
+					continue
+				}
+
+				names, skip := structFieldTagNames(f)
+				if skip {
+					continue
+				}
+				if f.Anonymous && len(names) == 0 && ft.Kind() == reflect.Struct {
+					next = append(next, level{typ: ft, index: index})
+					continue
+				}
+				if !exported {
+					continue
+				}
+
+				primary := f.Name
+				if len(names) > 0 {
+					primary = names[0]
+				}
+				add := func(name string, tagged bool) {
+					candidates[name] = append(candidates[name], candidate{structField{index, primary, tagged}, depth})
+				}
+				add(f.Name, false)
+				for _, name := range names {
+					if name != f.Name {
+						add(name, true)
+					}
+				}
+			}
+		}
+		current = next
+	}
+
+	fields = map[string]structField{}
+	for name, list := range candidates {
+		dominant := []candidate{}
+		for _, c := range list {
+			if len(dominant) == 0 || c.depth < dominant[0].depth {
+				dominant = []candidate{c}
+			} else if c.depth == dominant[0].depth {
+				dominant = append(dominant, c)
+			}
+		}
+		if len(dominant) == 1 {
+			fields[name] = dominant[0].structField
+			continue
+		}
+		tagged := []candidate{}
+		for _, c := range dominant {
+			if c.tagged {
+				tagged = append(tagged, c)
+			}
+		}
+		if len(tagged) == 1 {
+			fields[name] = tagged[0].structField
+		}
+	}
+
+	structFieldsCache.mux.Lock()
+	structFieldsCache.data[t] = fields
+	structFieldsCache.mux.Unlock()
+	return fields
+}
+
+// structFieldNames returns one name per accessible field of given struct type, in order of the fields.
+// The name is the first tag name of the field, if any, otherwise the Go field name.
+func structFieldNames(t reflect.Type) []string {
+	byIndex := map[string]string{}
+	indices := map[string][]int{}
+	for name, field := range structFields(t) {
+		key := fmt.Sprint(field.index)
+		if current, ok := byIndex[key]; !ok || name == field.name || (current != field.name && name < current) {
+			byIndex[key] = name
+			indices[key] = field.index
+		}
+	}
+	keys := make([]string, 0, len(byIndex))
+	for key := range byIndex {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := indices[keys[i]], indices[keys[j]]
+		for k := 0; k < len(a) && k < len(b); k++ {
+			if a[k] != b[k] {
+				return a[k] < b[k]
+			}
+		}
+		return len(a) < len(b)
+	})
+	names := make([]string, len(keys))
+	for i, key := range keys {
+		names[i] = byIndex[key]
+	}
+	return names
+}
+
+// structFieldTagNames returns the names given to a field by its struct tags and whether the field is
+// excluded from path access altogether (`gpath:"-"`, or `json:"-"` unless the gpath tag names it)
+func structFieldTagNames(f reflect.StructField) ([]string, bool) {
+	names := []string{}
+	for _, tag := range structTags {
+		value, ok := f.Tag.Lookup(tag)
+		if !ok {
+			continue
+		}
+		name := value
+		if idx := strings.Index(value, ","); idx > -1 {
+			name = value[0:idx]
+		}
+		if name == "-" && value == "-" {
+			if tag == "gpath" || (tag == "json" && len(names) == 0) {
+				return nil, true
+			}
+			continue
+		}
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+	return names, false
+}
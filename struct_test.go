@@ -0,0 +1,220 @@
+package gpath
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+type testStructTLS struct {
+	Cert string `json:"cert"`
+	Key  string `yaml:"key_file"`
+}
+
+type testStructBase struct {
+	Name    string
+	Version int `gpath:"ver"`
+}
+
+type testStructOther struct {
+	Other string
+}
+
+type testStructPipeline struct {
+	Steps []string
+}
+
+type testStructServer struct {
+	testStructBase
+	*testStructOther
+	Host    string         `json:"host,omitempty"`
+	TLS     testStructTLS  `json:"tls"`
+	Hidden  string         `gpath:"-"`
+	Ignored string         `json:"-"`
+	Ptr     *testStructTLS `json:"ptr"`
+	hidden  string
+}
+
+func TestStructField(t *testing.T) {
+	server := testStructServer{
+		testStructBase: testStructBase{Name: "base", Version: 3},
+		Host:           "localhost",
+		TLS:            testStructTLS{Cert: "cert.pem", Key: "key.pem"},
+		Hidden:         "hidden",
+		Ignored:        "ignored",
+		hidden:         "unexported",
+	}
+	type res struct {
+		val   interface{}
+		found bool
+	}
+	expects := []struct {
+		from interface{}
+		name string
+		to   *res
+	}{
+		{server, "Host", &res{"localhost", true}},
+		{server, "host", &res{"localhost", true}},
+		{server, "tls", &res{testStructTLS{Cert: "cert.pem", Key: "key.pem"}, true}},
+		{server, "Name", &res{"base", true}},
+		{server, "Version", &res{3, true}},
+		{server, "ver", &res{3, true}},
+		{server, "Hidden", &res{nil, false}},
+		{server, "Ignored", &res{nil, false}},
+		{server, "-", &res{nil, false}},
+		{server, "hidden", &res{nil, false}},
+		{server, "Ptr", &res{(*testStructTLS)(nil), true}},
+		{server, "other", &res{nil, false}},
+		{server.TLS, "cert", &res{"cert.pem", true}},
+		{server.TLS, "key_file", &res{"key.pem", true}},
+		{server.TLS, "Key", &res{"key.pem", true}},
+		{&server, "Host", &res{nil, false}},
+		{map[string]interface{}{"Host": "bar"}, "Host", &res{nil, false}},
+		{"not-struct", "Host", &res{nil, false}},
+	}
+
+	for _, e := range expects {
+		res, found := StructField(e.from, e.name)
+		assert.Equal(t, e.to.found, found, fmt.Sprintf("Expect found = %v (name: %s, from %###v)", e.to.found, e.name, e.from))
+		if e.to.found {
+			assert.Equal(t, e.to.val, res, fmt.Sprintf("Result is %v (name: %s, from %###v)", e.to.val, e.name, e.from))
+		}
+	}
+}
+
+func TestStructFieldSet(t *testing.T) {
+	tls := testStructTLS{Cert: "cert.pem"}
+	assert.True(t, StructFieldSet(&tls, "key_file", "key.pem"))
+	assert.Equal(t, "key.pem", tls.Key)
+	assert.False(t, StructFieldSet(&tls, "cert", 123), "int is not string")
+	assert.True(t, StructFieldSet(&tls, "cert", 123, true), "int is casted into string")
+	assert.Equal(t, "123", tls.Cert)
+	assert.False(t, StructFieldSet(tls, "cert", "x"), "struct must be given as pointer")
+	assert.False(t, StructFieldSet(&tls, "missing", "x"))
+
+	server := testStructServer{}
+	assert.False(t, StructFieldSet(&server, "hidden", "x"), "unexported field is not writable")
+	assert.True(t, StructFieldSet(&server, "ptr", nil), "nil for pointer field")
+}
+
+func TestStructField_Ignored(t *testing.T) {
+	type secrets struct {
+		Password string `json:"-"`
+		Token    string `gpath:"token" json:"-"`
+		Dash     string `json:"-,"`
+	}
+	s := secrets{Password: "p", Token: "t", Dash: "d"}
+
+	_, found := StructField(s, "Password")
+	assert.False(t, found, `json:"-" hides the field as encoding/json does`)
+
+	val, found := StructField(s, "token")
+	assert.True(t, found, "gpath tag name wins over json:\"-\"")
+	assert.Equal(t, "t", val)
+
+	val, found = StructField(s, "-")
+	assert.True(t, found, `json:"-," names the field "-"`)
+	assert.Equal(t, "d", val)
+	assert.Equal(t, []string{"token", "-"}, structFieldNames(reflect.TypeOf(s)))
+}
+
+func TestStructField_Embedded(t *testing.T) {
+	type inner struct {
+		Name  string `json:"name"`
+		Value string
+	}
+	type conflicting struct {
+		Value string
+	}
+	type outer struct {
+		inner
+		conflicting
+		Name string
+	}
+	type Inner struct {
+		Name string
+	}
+	type tagged struct {
+		Inner `json:"inner"`
+	}
+	o := outer{inner: inner{Name: "inner", Value: "a"}, conflicting: conflicting{Value: "b"}, Name: "outer"}
+
+	val, found := StructField(o, "Name")
+	assert.True(t, found, "shallow field found")
+	assert.Equal(t, "outer", val, "shallow field wins over promoted field")
+
+	val, found = StructField(o, "name")
+	assert.True(t, found, "promoted tagged field found")
+	assert.Equal(t, "inner", val, "promoted tagged field value")
+
+	_, found = StructField(o, "Value")
+	assert.False(t, found, "ambiguous promoted field is not found")
+
+	val, found = StructField(tagged{Inner: Inner{Name: "x"}}, "inner")
+	assert.True(t, found, "tagged embedded struct is a field")
+	assert.Equal(t, Inner{Name: "x"}, val, "tagged embedded struct is a field")
+
+	_, found = StructField(tagged{}, "Name")
+	assert.False(t, found, "tagged embedded struct is not promoted")
+
+	_, found = StructField(testStructServer{}, "Other")
+	assert.False(t, found, "promoted through nil embedded pointer is not found")
+
+	_, found = StructField(testStructServer{}, "Name")
+	assert.True(t, found, "promoted through value embedded struct")
+}
+
+func TestGPath_Struct(t *testing.T) {
+	gp := New(map[string]interface{}{
+		"server": testStructServer{
+			Host: "localhost",
+			TLS:  testStructTLS{Cert: "cert.pem"},
+		},
+		"servers": []testStructTLS{{Cert: "a.pem"}, {Cert: "b.pem"}},
+	})
+	assert.Equal(t, "localhost", gp.GetString("server.host"))
+	assert.Equal(t, "cert.pem", gp.GetString("server.tls.cert"))
+	assert.Equal(t, "cert.pem", gp.GetString("server.TLS.Cert"))
+	assert.Equal(t, "b.pem", gp.GetString("servers.1.cert"))
+	assert.False(t, gp.Has("server.tls.other"))
+	assert.False(t, gp.Has("server.0"))
+
+	gp = New(testStructTLS{Cert: "root.pem"})
+	assert.Equal(t, "root.pem", gp.GetString("cert"))
+}
+
+func TestGPath_SetStruct(t *testing.T) {
+	server := testStructServer{Host: "localhost", TLS: testStructTLS{Cert: "cert.pem"}}
+	data := map[string]interface{}{
+		"server":  testStructServer{TLS: testStructTLS{Cert: "a.pem"}},
+		"servers": []testStructTLS{{Cert: "a.pem"}, {Cert: "b.pem"}},
+	}
+	gp := New(&server)
+	assert.Nil(t, gp.Set("host", "example.com"))
+	assert.Equal(t, "example.com", server.Host, "field of struct referenced by pointer")
+	assert.Nil(t, gp.Set("ver", "4"))
+	assert.Equal(t, 4, server.Version, "value is casted into kind of promoted field")
+	assert.Nil(t, gp.Set("tls.cert", "new.pem"))
+	assert.Equal(t, "new.pem", server.TLS.Cert, "struct field is written back into its parent")
+	assert.Equal(t, "new.pem", gp.Get("tls.cert"))
+	assert.Nil(t, gp.Set("ptr", &testStructTLS{}))
+	assert.Nil(t, gp.Set("ptr.key_file", "key.pem"))
+	assert.Equal(t, "key.pem", server.Ptr.Key, "field of struct behind pointer")
+
+	assert.EqualError(t, gp.Set("missing", 1), "could not set missing in . (provided struct has no field missing)")
+	assert.EqualError(t, gp.Set("Other", "x"), "could not set Other in . (provided struct has no field Other)", "promoted through nil pointer")
+	assert.EqualError(t, gp.Set("host", []string{}), "could not set host in . (provided value is of slice kind and cannot be cast into string kind)")
+	assert.Equal(t, "example.com", server.Host)
+	assert.EqualError(t, New(server).Set("host", "x"), "parent element cannot be struct. Provide either pointer to struct or struct embedded within maps or slices")
+
+	gp = New(data)
+	assert.Nil(t, gp.Set("server.tls.key_file", "key.pem"))
+	assert.Equal(t, testStructTLS{Cert: "a.pem", Key: "key.pem"}, data["server"].(testStructServer).TLS, "struct in map is written back")
+	assert.Nil(t, gp.Set("servers.1.cert", "c.pem"))
+	assert.Equal(t, []testStructTLS{{Cert: "a.pem"}, {Cert: "c.pem"}}, data["servers"], "struct in slice is written back")
+	for _, match := range gp.Query("servers.*.cert") {
+		assert.Nil(t, gp.Set(match.Path, "all.pem"), "query paths can be fed back into Set")
+	}
+	assert.Equal(t, []string{"all.pem", "all.pem"}, gp.QueryStrings("servers.*.cert"))
+}
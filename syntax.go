@@ -0,0 +1,206 @@
+package gpath
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+)
+
+// Dialect is a notation of paths, see WithDialect
+type Dialect int
+
+const (
+	// Dotted is the default notation (`users.0.name`, `hosts["example.com"].port`), which supports
+	// wildcards, filters and ranges (see GPath.Query). The separator can be changed with WithSeparator.
+	Dotted Dialect = iota
+
+	// Bracket is the notation of nested form parameters (`users[0][name]`), in which keys in brackets
+	// need no quotes. Wildcards (`users[*][name]`), filters and ranges are supported as in Dotted.
+	Bracket
+
+	// Pointer is the JSON Pointer notation (`/users/0/name`), see GPath.GetPointer
+	Pointer
+)
+
+// Option configures a GPath, see New
+type Option func(*GPath)
+
+// WithSeparator sets the separator of segments for the Dotted dialect, eg "/" for Consul-style keys
+// (`service/db/port`), ":" or "->". Env-var-style keys use "__" (`DATABASE__HOSTS__0`). Other than in
+// the default notation, dots are part of the keys then (`spring.datasource/url`).
+func WithSeparator(separator string) Option {
+	return func(gp *GPath) {
+		if separator != "" {
+			gp.syntax.separator = separator
+		}
+	}
+}
+
+// WithDialect sets the notation of all paths provided to the GPath methods and of the paths of query
+// matches. Predicate expressions and precompiled paths (see Compile and P) always use the default
+// notation.
+func WithDialect(dialect Dialect) Option {
+	return func(gp *GPath) {
+		gp.syntax.dialect = dialect
+	}
+}
+
+// syntax is the notation of paths of a GPath instance
+type syntax struct {
+	dialect   Dialect
+	separator string
+}
+
+var defaultSyntax = syntax{dialect: Dotted, separator: "."}
+
+// parse returns the segments of a path in the notation of the GPath. JSON Pointers are resolved against
+// the data, see pointerSegments.
+func (gp *GPath) parse(path string, write bool) ([]segment, error) {
+	switch gp.syntax.dialect {
+	case Bracket:
+		return parseBracketPath(path)
+	case Pointer:
+		return gp.pointerSegments(path, write, false)
+	}
+	return parsePathSeparator(path, gp.syntax.separator)
+}
+
+// parseDeep works as parse for writing, but JSON Pointers may refer to missing parents, see SetDeep
+func (gp *GPath) parseDeep(path string) ([]segment, error) {
+	if gp.syntax.dialect == Pointer {
+		return gp.pointerSegments(path, true, true)
+	}
+	return gp.parse(path, true)
+}
+
+// format returns the path of concrete segments in the notation of the GPath
+func (gp *GPath) format(segments []segment) string {
+	switch gp.syntax.dialect {
+	case Bracket:
+		return formatBracketPath(segments)
+	case Pointer:
+		return formatPointer(segments)
+	}
+	return formatPathSeparator(segments, gp.syntax.separator)
+}
+
+// parseBracketPath splits a path in Bracket notation (`users[0][name]`). The first segment can be
+// given without brackets. Within brackets, any character can be escaped with a backslash and quoted
+// keys (`["0"]`), indices, ranges, wildcards (`[*]`, `[**]`) and filters work as in parsePath.
+func parseBracketPath(path string) ([]segment, error) {
+	segments := []segment{}
+	pos := 0
+	if path == "" || path[0] != '[' {
+		seg, end, err := parseBare(path, 0, "")
+		if err != nil {
+			return nil, err
+		}
+		segments = append(segments, seg)
+		pos = end
+	}
+	for pos < len(path) {
+		if path[pos] != '[' {
+			return nil, pathErrorf(path, pos, "unexpected %q", path[pos])
+		}
+		var seg segment
+		var err error
+		if c := byteAt(path, pos+1); c == '"' || c == '\'' || c == '?' {
+			seg, pos, err = parseBracket(path, pos)
+		} else {
+			seg, pos, err = parseBracketKey(path, pos)
+		}
+		if err != nil {
+			return nil, err
+		}
+		segments = append(segments, seg)
+	}
+	return segments, nil
+}
+
+// parseBracketKey reads an unquoted key, index, range or wildcard in brackets starting at the opening
+// bracket at pos
+func parseBracketKey(path string, pos int) (segment, int, error) {
+	start := pos
+	buf := new(bytes.Buffer)
+	escaped := false
+	for pos++; pos < len(path) && path[pos] != ']'; pos++ {
+		if path[pos] == '\\' {
+			if pos+1 >= len(path) {
+				return segment{}, pos, pathErrorf(path, pos, "dangling escape")
+			}
+			pos++
+			escaped = true
+		} else if path[pos] == '[' {
+			return segment{}, pos, pathErrorf(path, pos, "unexpected %q", path[pos])
+		}
+		buf.WriteByte(path[pos])
+	}
+	if pos >= len(path) {
+		return segment{}, pos, pathErrorf(path, start, "unclosed bracket")
+	}
+	word := buf.String()
+	if escaped {
+		return keySegment(word), pos + 1, nil
+	} else if word == "*" {
+		return wildcardSegment(), pos + 1, nil
+	} else if word == "**" {
+		return recursiveSegment(), pos + 1, nil
+	} else if isInt(word) || isRange(word) {
+		return parseBracketIndex(path, start)
+	}
+	return keySegment(word), pos + 1, nil
+}
+
+// formatBracketPath returns the Bracket notation of given segments
+func formatBracketPath(segments []segment) string {
+	buf := new(bytes.Buffer)
+	for i, seg := range segments {
+		switch {
+		case seg.kind == segmentFilter || seg.kind == segmentRange:
+			buf.WriteString(seg.String())
+		case seg.kind != segmentKey:
+			buf.WriteString("[" + seg.String() + "]")
+		case i == 0 && isBareBracketKey(seg.key, false):
+			buf.WriteString(seg.key)
+		case isBareBracketKey(seg.key, true):
+			buf.WriteString("[" + seg.key + "]")
+		default:
+			buf.WriteString(quoteKey(seg.key))
+		}
+	}
+	return buf.String()
+}
+
+// isBareBracketKey returns whether key can be written without quoting in Bracket notation, either
+// within brackets or as first segment before any bracket
+func isBareBracketKey(key string, inBrackets bool) bool {
+	if key == "" || key == "*" || key == "**" || isInt(key) || strings.ContainsAny(key, "[]\\") {
+		return false
+	} else if inBrackets {
+		return !isRange(key) && key[0] != '"' && key[0] != '\'' && key[0] != '?'
+	}
+	return true
+}
+
+// isRange returns whether word is a range as given in brackets (`1:3`, `:3`, `-2:`)
+func isRange(word string) bool {
+	parts := strings.Split(word, ":")
+	if len(parts) != 2 {
+		return false
+	}
+	for _, part := range parts {
+		if part != "" {
+			if _, err := strconv.Atoi(part); err != nil || !isInt(part) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func byteAt(str string, pos int) byte {
+	if pos < len(str) {
+		return str[pos]
+	}
+	return 0
+}
@@ -0,0 +1,163 @@
+package gpath
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func testSyntaxData() map[string]interface{} {
+	return map[string]interface{}{
+		"spring.datasource": map[string]interface{}{"url": "jdbc:x"},
+		"service": map[string]interface{}{
+			"db": map[string]interface{}{"port": 5432, "hosts": []interface{}{"a", "b"}},
+		},
+		"DATABASE": map[string]interface{}{"HOST": "db", "REPLICAS": []interface{}{"r1", "r2"}},
+		"a/b":      1,
+	}
+}
+
+func TestGPath_WithSeparator(t *testing.T) {
+	expects := []struct {
+		separator string
+		path      string
+		expect    interface{}
+	}{
+		{"/", "service/db/port", 5432},
+		{"/", "service/db/hosts/1", "b"},
+		{"/", "service/db/hosts/-1", "b"},
+		{"/", "service/db/hosts[0]", "a"},
+		{"/", "spring.datasource/url", "jdbc:x"},
+		{"/", `a\/b`, 1},
+		{"/", `["a/b"]`, 1},
+		{":", "service:db:port", 5432},
+		{"->", "service->db->hosts->-1", "b"},
+		{"->", "service->db->hosts->0", "a"},
+		{"__", "DATABASE__HOST", "db"},
+		{"__", "DATABASE__REPLICAS__1", "r2"},
+		{".", "service.db.port", 5432},
+		{"", "service.db.port", 5432},
+	}
+	for _, expect := range expects {
+		gp := New(testSyntaxData(), WithSeparator(expect.separator))
+		assert.Equal(t, expect.expect, gp.Get(expect.path), "Get %s with separator %q", expect.path, expect.separator)
+	}
+
+	gp := New(testSyntaxData(), WithSeparator("/"))
+	assert.False(t, gp.Has("service.db.port"), "dots are part of keys")
+	assert.Nil(t, gp.Set("service/db/port", 1234))
+	assert.Equal(t, int64(1234), gp.GetInt("service/db/port"))
+	assert.Nil(t, gp.Set("service/db/hosts/-1", "c"))
+	assert.Equal(t, []string{"a", "b", "c"}, gp.GetStrings("service/db/hosts"))
+	assert.Equal(t, []Match{
+		{"service/db/hosts/0", "a"},
+		{"service/db/hosts/1", "b"},
+		{"service/db/hosts/2", "c"},
+	}, gp.Query("service/db/hosts/*"), "match paths use separator")
+	assert.Equal(t, []Match{{`["a/b"]`, 1}, {"spring.datasource/url", "jdbc:x"}}, gp.Query("**[?@ == 1 || @ == 'jdbc:x']"))
+	assert.Equal(t, "db", gp.GetChild("DATABASE").Get("HOST"), "child uses same separator")
+	assert.Equal(t, 1234, gp.GetChild("service").Get("db/port"), "child uses same separator")
+
+	assert.EqualError(t, gp.Set("service/db/port/x", 1), "could not set service/db/port/x in .service/db/port (int)", "write errors use separator")
+	assert.EqualError(t, gp.Set("service/missing/x", 1), "parent element service/missing does not exist")
+	assert.EqualError(t, gp.Delete("service/db/hosts/*"), "cannot delete service/db/hosts/*, as it does not address a single element")
+	assert.EqualError(t, gp.SetDeep("service/new/3", 1), "could not set service/new/3 in .service/new (provided index 3 is out of bounds for slice of len 0)")
+	assert.EqualError(t, gp.RemoveAt("service/db/port", 0), "could not modify service/db/port, as it is not a slice (int)")
+
+	matches, err := gp.JSONPath("$.service.db.hosts[0]")
+	assert.Nil(t, err)
+	assert.Equal(t, "service/db/hosts/0", matches[0].Path, "JSONPath match paths use separator")
+}
+
+func TestGPath_WithDialectBracket(t *testing.T) {
+	gp := New(testSyntaxData(), WithDialect(Bracket))
+	expects := []struct {
+		path   string
+		expect interface{}
+	}{
+		{"service[db][port]", 5432},
+		{"service[db][hosts][1]", "b"},
+		{"service[db][hosts][-1]", "b"},
+		{"[service][db][port]", 5432},
+		{"spring.datasource[url]", "jdbc:x"},
+		{"a/b", 1},
+		{`service["db"]['port']`, 5432},
+		{"service[db][hosts][1:]", []interface{}{"b"}},
+		{"service[db][other]", nil},
+		{"service[db", nil},
+		{"service]", nil},
+		{"service[db]x", nil},
+	}
+	for _, expect := range expects {
+		assert.Equal(t, expect.expect, gp.Get(expect.path), "Get %s", expect.path)
+	}
+
+	assert.Nil(t, gp.Set("service[db][port]", 1))
+	assert.Equal(t, 1, gp.Get("service[db][port]"))
+	assert.Nil(t, gp.Set("service[db][hosts][-1]", "c"))
+	assert.Equal(t, []Match{
+		{"service[db][hosts][0]", "a"},
+		{"service[db][hosts][1]", "b"},
+		{"service[db][hosts][2]", "c"},
+	}, gp.Query("service[db][hosts][*]"))
+	assert.Equal(t, []Match{{"DATABASE[REPLICAS][1]", "r2"}}, gp.Query("DATABASE[REPLICAS][?@ == 'r2']"))
+	assert.Equal(t, "c", gp.GetChild("service").Get("db[hosts][2]"), "child uses same dialect")
+}
+
+func TestGPath_WithDialectPointer(t *testing.T) {
+	gp := New(testSyntaxData(), WithDialect(Pointer))
+	assert.Equal(t, 5432, gp.Get("/service/db/port"))
+	assert.Equal(t, "b", gp.Get("/service/db/hosts/1"))
+	assert.Equal(t, 1, gp.Get("/a~1b"))
+	assert.Equal(t, "jdbc:x", gp.GetString("/spring.datasource/url"))
+	assert.False(t, gp.Has("service.db.port"))
+
+	assert.Nil(t, gp.Set("/service/db/hosts/-", "c"), "- appends")
+	assert.Equal(t, []string{"a", "b", "c"}, gp.GetStrings("/service/db/hosts"))
+	assert.Equal(t, []Match{{"/service/db/hosts/2", "c"}}, gp.Filter("/service/db/hosts", Eq("@", "c")))
+	assert.Equal(t, []Match{{"/a~1b", 1}}, gp.Query("/a~1b"))
+	assert.Equal(t, "db", gp.GetChild("/DATABASE").Get("/HOST"), "child uses same dialect")
+}
+
+func Test_formatBracketPath(t *testing.T) {
+	expects := []struct {
+		segments []segment
+		expect   string
+	}{
+		{[]segment{keySegment("users"), indexSegment(0), keySegment("name")}, "users[0][name]"},
+		{[]segment{keySegment("a.b"), keySegment("c/d")}, "a.b[c/d]"},
+		{[]segment{keySegment("0"), keySegment("1")}, `["0"]["1"]`},
+		{[]segment{keySegment("a"), keySegment("1:2"), keySegment("?x"), keySegment(`"q`), keySegment("a]")}, `a["1:2"]["?x"]["\"q"]["a]"]`},
+		{[]segment{keySegment("a"), wildcardSegment(), recursiveSegment(), indexSegment(-1)}, "a[*][**][-1]"},
+		{[]segment{keySegment("a"), rangeSegment(1, 0, true)}, "a[1:]"},
+		{[]segment{keySegment("")}, `[""]`},
+	}
+	for _, expect := range expects {
+		path := formatBracketPath(expect.segments)
+		assert.Equal(t, expect.expect, path, "Segments should be formatted")
+		segments, err := parseBracketPath(path)
+		assert.Nil(t, err, "Formatted path %s should parse", path)
+		assert.Equal(t, expect.segments, segments, "Formatted path %s should parse back", path)
+	}
+}
+
+func Test_formatPathSeparator(t *testing.T) {
+	expects := []struct {
+		segments  []segment
+		separator string
+		expect    string
+	}{
+		{[]segment{keySegment("a.b"), keySegment("c")}, "/", "a.b/c"},
+		{[]segment{keySegment("a/b"), keySegment("c")}, "/", `["a/b"]/c`},
+		{[]segment{keySegment("a_"), keySegment("b")}, "__", `["a_"]__b`},
+		{[]segment{keySegment("a"), indexSegment(-1)}, "->", "a->-1"},
+		{[]segment{keySegment("a"), indexSegment(1), keySegment("b")}, "->", "a->1->b"},
+		{[]segment{keySegment("a"), indexSegment(-1)}, "-", "a[-1]"},
+	}
+	for _, expect := range expects {
+		path := formatPathSeparator(expect.segments, expect.separator)
+		assert.Equal(t, expect.expect, path, "Segments should be formatted")
+		segments, err := parsePathSeparator(path, expect.separator)
+		assert.Nil(t, err, "Formatted path %s should parse", path)
+		assert.Equal(t, expect.segments, segments, "Formatted path %s should parse back", path)
+	}
+}